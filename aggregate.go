@@ -0,0 +1,151 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrInvalidWeight is returned when a weight is not a finite number, or is negative where weights must not be.
+	ErrInvalidWeight = errors.New("invalid weight")
+
+	// ErrPrecisionMismatch is returned when combining values computed at different precisions.
+	ErrPrecisionMismatch = errors.New("precision mismatch")
+)
+
+type (
+	// Bucket is the aggregation of the points falling within one GeoHash cell.
+	Bucket struct {
+		// Hash identifies the cell.
+		Hash string
+		// Count is the number of points in the cell.
+		Count int
+		// Sum is the total weight of the points in the cell.
+		Sum float64
+		// Latitude and Longitude are the centroid of the points, weighted when the total weight is not zero.
+		Latitude  float64
+		Longitude float64
+		// BBox is the bounding box of the cell.
+		BBox BBox
+	}
+
+	// Aggregator buckets points into GeoHash cells at a fixed precision, like a geohash_grid aggregation.
+	// An Aggregator is not safe for concurrent use; aggregate in one Aggregator per worker and Merge the results.
+	Aggregator struct {
+		precision Precision
+		cells     map[string]*cellStats
+	}
+
+	// cellStats accumulates the running sums of one cell.
+	cellStats struct {
+		count          int
+		weight         float64
+		latSum, lngSum float64
+		wLatSum        float64
+		wLngSum        float64
+	}
+)
+
+// NewAggregator creates an empty Aggregator bucketing points at the given precision.
+// Returns an error if the precision is out of the valid range.
+func NewAggregator(precision Precision) (*Aggregator, error) {
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return nil, err
+	}
+
+	return &Aggregator{
+		precision: precision,
+		cells:     make(map[string]*cellStats),
+	}, nil
+}
+
+// Precision returns the precision at which the aggregator buckets points.
+func (a *Aggregator) Precision() Precision {
+	return a.precision
+}
+
+// Len returns the number of non-empty cells.
+func (a *Aggregator) Len() int {
+	return len(a.cells)
+}
+
+// Add records a point with a weight of 1.
+// Returns an error if the latitude or longitude is out of range.
+func (a *Aggregator) Add(latitude, longitude float64) error {
+	return a.AddWeighted(latitude, longitude, 1)
+}
+
+// AddWeighted records a point with the given weight.
+// Returns an error if the latitude or longitude is out of range, or if the weight is not finite.
+func (a *Aggregator) AddWeighted(latitude, longitude, weight float64) error {
+	if math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return ErrInvalidWeight
+	}
+
+	hash, err := Encode(latitude, longitude, a.precision)
+	if err != nil {
+		return err
+	}
+
+	stats := a.cells[hash]
+	if stats == nil {
+		stats = &cellStats{}
+		a.cells[hash] = stats
+	}
+	stats.count++
+	stats.weight += weight
+	stats.latSum += latitude
+	stats.lngSum += longitude
+	stats.wLatSum += weight * latitude
+	stats.wLngSum += weight * longitude
+	return nil
+}
+
+// Merge folds the cells of other into a, leaving other untouched.
+// Returns an error if the aggregators use different precisions.
+func (a *Aggregator) Merge(other *Aggregator) error {
+	if a.precision != other.precision {
+		return ErrPrecisionMismatch
+	}
+
+	for hash, o := range other.cells {
+		stats := a.cells[hash]
+		if stats == nil {
+			stats = &cellStats{}
+			a.cells[hash] = stats
+		}
+		stats.count += o.count
+		stats.weight += o.weight
+		stats.latSum += o.latSum
+		stats.lngSum += o.lngSum
+		stats.wLatSum += o.wLatSum
+		stats.wLngSum += o.wLngSum
+	}
+	return nil
+}
+
+// Buckets returns the aggregated cells sorted by hash.
+func (a *Aggregator) Buckets() []Bucket {
+	buckets := make([]Bucket, 0, len(a.cells))
+	for hash, stats := range a.cells {
+		_, _, bbox := MustDecodeBBox(hash)
+
+		b := Bucket{
+			Hash:      hash,
+			Count:     stats.count,
+			Sum:       stats.weight,
+			Latitude:  stats.latSum / float64(stats.count),
+			Longitude: stats.lngSum / float64(stats.count),
+			BBox:      bbox,
+		}
+		if stats.weight != 0 {
+			b.Latitude = stats.wLatSum / stats.weight
+			b.Longitude = stats.wLngSum / stats.weight
+		}
+		buckets = append(buckets, b)
+	}
+
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Hash < buckets[j].Hash })
+	return buckets
+}
@@ -0,0 +1,109 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAggregator(t *testing.T) {
+	_, err := NewAggregator(13)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	a, err := NewAggregator(City)
+	assert.NoError(t, err)
+	assert.Equal(t, City, a.Precision())
+	assert.Equal(t, 0, a.Len())
+	assert.Empty(t, a.Buckets())
+}
+
+func TestAggregatorAdd(t *testing.T) {
+	a, err := NewAggregator(State)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, a.Add(91, 0), ErrLatitudeOutOfRange)
+	assert.ErrorIs(t, a.AddWeighted(0, 0, math.NaN()), ErrInvalidWeight)
+	assert.ErrorIs(t, a.AddWeighted(0, 0, math.Inf(1)), ErrInvalidWeight)
+
+	require.NoError(t, a.Add(37.7749, -122.4194))
+	require.NoError(t, a.AddWeighted(37.5, -123.0, 3))
+	require.NoError(t, a.AddWeighted(40.7128, -74.0060, 0))
+	assert.Equal(t, 2, a.Len())
+
+	tests := []struct {
+		name          string
+		bucket        Bucket
+		wantHash      string
+		wantCount     int
+		wantSum       float64
+		wantLatitude  float64
+		wantLongitude float64
+	}{
+		{
+			name:          "Weighted centroid",
+			bucket:        a.Buckets()[0],
+			wantHash:      "9q8",
+			wantCount:     2,
+			wantSum:       4,
+			wantLatitude:  (37.7749 + 3*37.5) / 4,
+			wantLongitude: (-122.4194 + 3*-123.0) / 4,
+		},
+		{
+			name:          "Zero weight falls back to plain centroid",
+			bucket:        a.Buckets()[1],
+			wantHash:      "dr5",
+			wantCount:     1,
+			wantSum:       0,
+			wantLatitude:  40.7128,
+			wantLongitude: -74.0060,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantHash, tt.bucket.Hash)
+			assert.Equal(t, tt.wantCount, tt.bucket.Count)
+			assert.InDelta(t, tt.wantSum, tt.bucket.Sum, tolerance)
+			assert.InDelta(t, tt.wantLatitude, tt.bucket.Latitude, tolerance)
+			assert.InDelta(t, tt.wantLongitude, tt.bucket.Longitude, tolerance)
+			assert.True(t, tt.bucket.BBox.Contains(tt.bucket.Latitude, tt.bucket.Longitude))
+		})
+	}
+}
+
+func TestAggregatorMerge(t *testing.T) {
+	whole, _ := NewAggregator(City)
+	left, _ := NewAggregator(City)
+	right, _ := NewAggregator(City)
+
+	points := [][3]float64{
+		{37.7749, -122.4194, 1},
+		{37.7750, -122.4195, 2},
+		{37.8044, -122.2712, 1},
+		{40.7128, -74.0060, 5},
+	}
+	for i, p := range points {
+		require.NoError(t, whole.AddWeighted(p[0], p[1], p[2]))
+		part := left
+		if i%2 == 1 {
+			part = right
+		}
+		require.NoError(t, part.AddWeighted(p[0], p[1], p[2]))
+	}
+
+	require.NoError(t, left.Merge(right))
+	assert.Equal(t, len(whole.Buckets()), len(left.Buckets()))
+	for i, want := range whole.Buckets() {
+		got := left.Buckets()[i]
+		assert.Equal(t, want.Hash, got.Hash)
+		assert.Equal(t, want.Count, got.Count)
+		assert.InDelta(t, want.Sum, got.Sum, tolerance)
+		assert.InDelta(t, want.Latitude, got.Latitude, tolerance)
+		assert.InDelta(t, want.Longitude, got.Longitude, tolerance)
+	}
+
+	other, _ := NewAggregator(State)
+	assert.ErrorIs(t, left.Merge(other), ErrPrecisionMismatch)
+}
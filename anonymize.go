@@ -0,0 +1,121 @@
+package geohash
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidAnonymity is returned when the anonymity level k is less than one.
+var ErrInvalidAnonymity = errors.New("invalid anonymity")
+
+type (
+	// GeneralizedCell is a cell released by Generalize along with the number of points it holds.
+	GeneralizedCell struct {
+		Hash  string
+		Count int
+	}
+
+	// Generalization is the k-anonymous coarsening of a set of GeoHash points.
+	Generalization struct {
+		// Hashes holds the generalized hash of each input point, in input order; suppressed points are empty.
+		Hashes []string
+		// Cells holds the released cells in sorted order, each holding at least k points. A cell may lie
+		// within a coarser released cell, which then only counts the points not released in finer ones.
+		Cells []GeneralizedCell
+		// Suppressed is the number of points that could not be released at minPrecision or finer.
+		Suppressed int
+	}
+
+	// generalizedPoint is an input hash along with its position in the input.
+	generalizedPoint struct {
+		hash  string
+		index int
+	}
+)
+
+// Generalize coarsens the hashes of a set of points so that every released cell holds at least k of them.
+// The hash prefixes form a hierarchy: going up from the finest cells, points in cells holding k or more
+// points keep the longest such prefix, while the remaining ones merge into the parent cell, down to
+// minPrecision. Points still short of k at minPrecision are suppressed.
+// Returns an error if k is less than one, the precision is out of range, or any hash is invalid or shorter
+// than minPrecision.
+func Generalize(hashes []string, k int, minPrecision Precision) (Generalization, error) {
+	if k < 1 {
+		return Generalization{}, ErrInvalidAnonymity
+	}
+	if err := checkPrecision(int(minPrecision), int(Global), int(SubPoint)); err != nil {
+		return Generalization{}, err
+	}
+
+	points := make([]generalizedPoint, len(hashes))
+	for i, hash := range hashes {
+		if err := validateHash(hash); err != nil {
+			return Generalization{}, err
+		}
+		if len(hash) < int(minPrecision) {
+			return Generalization{}, &LengthError{Length: len(hash), Min: int(minPrecision), Max: int(SubPoint)}
+		}
+		points[i] = generalizedPoint{hash: hash, index: i}
+	}
+	sort.Slice(points, func(a, b int) bool {
+		return points[a].hash < points[b].hash
+	})
+
+	g := Generalization{Hashes: make([]string, len(hashes))}
+	for start := 0; start < len(points); {
+		prefix := points[start].hash[:minPrecision]
+		end := start + 1
+		for end < len(points) && strings.HasPrefix(points[end].hash, prefix) {
+			end++
+		}
+
+		g.Suppressed += len(g.generalize(points[start:end], int(minPrecision), k))
+		start = end
+	}
+
+	sort.Slice(g.Cells, func(a, b int) bool {
+		return g.Cells[a].Hash < g.Cells[b].Hash
+	})
+	return g, nil
+}
+
+// MustGeneralize coarsens the hashes of a set of points to k-anonymous cells or panics if an error occurs.
+func MustGeneralize(hashes []string, k int, minPrecision Precision) Generalization {
+	g, err := Generalize(hashes, k, minPrecision)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// generalize releases the sorted points sharing a prefix of the given depth, finest cells first, and returns
+// the points left over when fewer than k remain for the cell at depth.
+func (g *Generalization) generalize(points []generalizedPoint, depth, k int) []generalizedPoint {
+	var residual []generalizedPoint
+	for start := 0; start < len(points); {
+		if len(points[start].hash) == depth {
+			residual = append(residual, points[start])
+			start++
+			continue
+		}
+
+		end := start + 1
+		for end < len(points) && points[end].hash[depth] == points[start].hash[depth] {
+			end++
+		}
+		residual = append(residual, g.generalize(points[start:end], depth+1, k)...)
+		start = end
+	}
+
+	if len(residual) < k {
+		return residual
+	}
+
+	prefix := residual[0].hash[:depth]
+	for _, p := range residual {
+		g.Hashes[p.index] = prefix
+	}
+	g.Cells = append(g.Cells, GeneralizedCell{Hash: prefix, Count: len(residual)})
+	return nil
+}
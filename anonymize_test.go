@@ -0,0 +1,159 @@
+package geohash
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneralize(t *testing.T) {
+	hashes := []string{"9q8yy", "dr5ru", "9q8yz", "9q8yy", "9q8zz"}
+
+	tests := []struct {
+		name         string
+		hashes       []string
+		k            int
+		minPrecision Precision
+		want         Generalization
+		wantErr      error
+	}{
+		{
+			name:         "Identity with k of one",
+			hashes:       hashes,
+			k:            1,
+			minPrecision: Global,
+			want: Generalization{
+				Hashes: hashes,
+				Cells: []GeneralizedCell{
+					{"9q8yy", 2}, {"9q8yz", 1}, {"9q8zz", 1}, {"dr5ru", 1},
+				},
+			},
+		},
+		{
+			name:         "Sparse points merge into their parent",
+			hashes:       hashes,
+			k:            2,
+			minPrecision: Global,
+			want: Generalization{
+				Hashes:     []string{"9q8yy", "", "9q8", "9q8yy", "9q8"},
+				Cells:      []GeneralizedCell{{"9q8", 2}, {"9q8yy", 2}},
+				Suppressed: 1,
+			},
+		},
+		{
+			name:         "Minimum precision suppresses points",
+			hashes:       hashes,
+			k:            2,
+			minPrecision: City,
+			want: Generalization{
+				Hashes:     []string{"9q8yy", "", "", "9q8yy", ""},
+				Cells:      []GeneralizedCell{{"9q8yy", 2}},
+				Suppressed: 3,
+			},
+		},
+		{
+			name:         "Points released at different depths",
+			hashes:       []string{"9q8yy", "9q8", "9q8y", "9q8yy", "9q8yy"},
+			k:            3,
+			minPrecision: Country,
+			want: Generalization{
+				Hashes: []string{"9q8yy", "", "", "9q8yy", "9q8yy"},
+				Cells:  []GeneralizedCell{{"9q8yy", 3}},
+				// The cells 9q8y and 9q8 only hold one point each.
+				Suppressed: 2,
+			},
+		},
+		{
+			name:         "Empty input",
+			hashes:       nil,
+			k:            5,
+			minPrecision: Global,
+			want:         Generalization{Hashes: []string{}},
+		},
+		{
+			name:         "Invalid anonymity",
+			hashes:       hashes,
+			k:            0,
+			minPrecision: Global,
+			wantErr:      ErrInvalidAnonymity,
+		},
+		{
+			name:         "Invalid precision",
+			hashes:       hashes,
+			k:            2,
+			minPrecision: 0,
+			wantErr:      ErrPrecisionOutOfRange,
+		},
+		{
+			name:         "Invalid hash",
+			hashes:       []string{"9q8ya"},
+			k:            2,
+			minPrecision: Global,
+			wantErr:      ErrInvalidHashFormat,
+		},
+		{
+			name:         "Hash shorter than the minimum precision",
+			hashes:       []string{"9q8yy", "9q"},
+			k:            2,
+			minPrecision: State,
+			wantErr:      ErrInvalidHashLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Generalize(tt.hashes, tt.k, tt.minPrecision)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGeneralizeAnonymity(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	hashes := make([]string, 2000)
+	for i := range hashes {
+		// Cluster the points around a few centers so that both dense and sparse cells occur.
+		lat := 37.7 + float64(i%5)*0.05 + rng.NormFloat64()*0.01*float64(1+i%3)
+		lng := -122.4 + float64(i%7)*0.05 + rng.NormFloat64()*0.01
+		hashes[i] = MustEncode(lat, lng, House)
+	}
+
+	const k = 10
+	g, err := Generalize(hashes, k, Region)
+	require.NoError(t, err)
+
+	counts := make(map[string]int)
+	for i, hash := range g.Hashes {
+		if hash == "" {
+			continue
+		}
+		assert.True(t, strings.HasPrefix(hashes[i], hash))
+		assert.GreaterOrEqual(t, len(hash), int(Region))
+		counts[hash]++
+	}
+
+	released := 0
+	for i, cell := range g.Cells {
+		assert.GreaterOrEqual(t, cell.Count, k)
+		assert.Equal(t, counts[cell.Hash], cell.Count)
+		if i > 0 {
+			assert.Less(t, g.Cells[i-1].Hash, cell.Hash)
+		}
+		released += cell.Count
+	}
+	assert.Len(t, counts, len(g.Cells))
+	assert.Equal(t, len(hashes), released+g.Suppressed)
+}
+
+func TestMustGeneralize(t *testing.T) {
+	assert.Panics(t, func() { MustGeneralize([]string{"9q8yy"}, 0, Global) })
+	assert.Equal(t, []string{"9q8yy"}, MustGeneralize([]string{"9q8yy"}, 1, Global).Hashes)
+}
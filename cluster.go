@@ -0,0 +1,118 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrInvalidThreshold is returned when a cluster density threshold is lower than 1.
+var ErrInvalidThreshold = errors.New("invalid threshold")
+
+type (
+	// Marker is a map point, weighted for centroid computations.
+	Marker struct {
+		Latitude  float64
+		Longitude float64
+		Weight    float64
+	}
+
+	// Cluster is a group of markers sharing a GeoHash cell.
+	Cluster struct {
+		// Hash identifies the cell holding the markers.
+		Hash string
+		// Count is the number of markers in the cluster.
+		Count int
+		// Weight is the total weight of the markers.
+		Weight float64
+		// Latitude and Longitude are the centroid of the markers, weighted when the total weight is not zero.
+		Latitude  float64
+		Longitude float64
+		// BBox is the bounding box of the cell.
+		BBox BBox
+	}
+)
+
+// ClusterMarkers groups the markers lying within the viewport into clusters for the given zoom level.
+// Markers are first bucketed at the precision matching the zoom level; any bucket holding more than
+// threshold markers is split into finer cells, down to SubPoint precision. Clusters are sorted by hash.
+// Weights must be non-negative and finite, so that centroids stay within their cells.
+// Returns an error if the viewport, zoom or threshold is invalid, if a marker is out of range, or if a
+// weight is negative, NaN or infinite.
+func ClusterMarkers(markers []Marker, viewport BBox, zoom int, threshold int) ([]Cluster, error) {
+	if err := validateBBox(viewport); err != nil {
+		return nil, err
+	}
+	if zoom < 0 || zoom > maxZoom {
+		return nil, ErrInvalidZoom
+	}
+	if threshold < 1 {
+		return nil, ErrInvalidThreshold
+	}
+
+	// Encode every marker once; coarser cells are prefixes of the full-precision hash.
+	var visible []hashedMarker
+	for i, m := range markers {
+		if m.Weight < 0 || !isFinite(m.Weight) {
+			return nil, fmt.Errorf("%w: marker %d has weight %v", ErrInvalidWeight, i, m.Weight)
+		}
+		hash, err := Encode(m.Latitude, m.Longitude, SubPoint)
+		if err != nil {
+			return nil, err
+		}
+		if viewport.Contains(m.Latitude, m.Longitude) {
+			visible = append(visible, hashedMarker{Marker: m, hash: hash})
+		}
+	}
+
+	var clusters []Cluster
+	drillClusters(visible, zoomPrecision(zoom), threshold, &clusters)
+
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Hash < clusters[j].Hash })
+	return clusters, nil
+}
+
+// hashedMarker is a marker along with its SubPoint GeoHash.
+type hashedMarker struct {
+	Marker
+	hash string
+}
+
+// drillClusters buckets markers by their prefix at the given precision and splits dense buckets further.
+func drillClusters(markers []hashedMarker, precision Precision, threshold int, clusters *[]Cluster) {
+	buckets := make(map[string][]hashedMarker)
+	for _, m := range markers {
+		prefix := m.hash[:precision]
+		buckets[prefix] = append(buckets[prefix], m)
+	}
+
+	for hash, bucket := range buckets {
+		if len(bucket) > threshold && precision < SubPoint {
+			drillClusters(bucket, precision+1, threshold, clusters)
+			continue
+		}
+		*clusters = append(*clusters, newCluster(hash, bucket))
+	}
+}
+
+// newCluster summarizes the markers of one cell.
+func newCluster(hash string, markers []hashedMarker) Cluster {
+	_, _, bbox := MustDecodeBBox(hash)
+	c := Cluster{Hash: hash, Count: len(markers), BBox: bbox}
+
+	var latSum, lngSum, wLatSum, wLngSum float64
+	for _, m := range markers {
+		c.Weight += m.Weight
+		latSum += m.Latitude
+		lngSum += m.Longitude
+		wLatSum += m.Weight * m.Latitude
+		wLngSum += m.Weight * m.Longitude
+	}
+
+	if c.Weight != 0 {
+		c.Latitude, c.Longitude = wLatSum/c.Weight, wLngSum/c.Weight
+	} else {
+		c.Latitude, c.Longitude = latSum/float64(c.Count), lngSum/float64(c.Count)
+	}
+	return c
+}
@@ -0,0 +1,147 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterMarkers(t *testing.T) {
+	world := BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180}
+	markers := []Marker{
+		{Latitude: 37.7749, Longitude: -122.4194, Weight: 1},
+		{Latitude: 37.7750, Longitude: -122.4195, Weight: 1},
+		{Latitude: 37.8044, Longitude: -122.2712, Weight: 2},
+		{Latitude: 40.7128, Longitude: -74.0060, Weight: 0},
+		{Latitude: 48.8566, Longitude: 2.3522, Weight: 1},
+	}
+
+	tests := []struct {
+		name      string
+		markers   []Marker
+		viewport  BBox
+		zoom      int
+		threshold int
+		want      []string
+		wantErr   error
+	}{
+		{
+			name:      "Invalid viewport",
+			viewport:  BBox{MinLatitude: 10, MaxLatitude: 0},
+			threshold: 1,
+			wantErr:   ErrInvalidBBox,
+		},
+		{
+			name:      "Invalid zoom",
+			viewport:  world,
+			zoom:      31,
+			threshold: 1,
+			wantErr:   ErrInvalidZoom,
+		},
+		{
+			name:      "Invalid threshold",
+			viewport:  world,
+			threshold: 0,
+			wantErr:   ErrInvalidThreshold,
+		},
+		{
+			name:      "Invalid marker",
+			markers:   []Marker{{Latitude: 91}},
+			viewport:  world,
+			threshold: 1,
+			wantErr:   ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Negative weight",
+			markers:   []Marker{{Latitude: 89, Weight: 2}, {Latitude: 46, Weight: -1}},
+			viewport:  world,
+			threshold: 10,
+			wantErr:   ErrInvalidWeight,
+		},
+		{
+			name:      "NaN weight",
+			markers:   []Marker{{Latitude: 0, Weight: math.NaN()}},
+			viewport:  world,
+			threshold: 1,
+			wantErr:   ErrInvalidWeight,
+		},
+		{
+			name:      "Infinite weight",
+			markers:   []Marker{{Latitude: 0, Weight: math.Inf(1)}},
+			viewport:  world,
+			threshold: 1,
+			wantErr:   ErrInvalidWeight,
+		},
+		{
+			name:      "Invalid weight outside the viewport",
+			markers:   []Marker{{Latitude: 0, Weight: math.Inf(-1)}},
+			viewport:  BBox{MinLatitude: 10, MaxLatitude: 20, MinLongitude: 10, MaxLongitude: 20},
+			threshold: 1,
+			wantErr:   ErrInvalidWeight,
+		},
+		{
+			name:      "Sparse cells stay coarse",
+			markers:   markers,
+			viewport:  world,
+			zoom:      3,
+			threshold: 10,
+			want:      []string{"9", "d", "u"},
+		},
+		{
+			name:      "Dense cells drill down",
+			markers:   markers,
+			viewport:  world,
+			zoom:      3,
+			threshold: 1,
+			want:      []string{"9q8yyk8y", "9q8yyk8z", "9q9", "d", "u"},
+		},
+		{
+			name:      "Viewport filters markers",
+			markers:   markers,
+			viewport:  BBox{MinLatitude: 30, MaxLatitude: 50, MinLongitude: -130, MaxLongitude: -60},
+			zoom:      3,
+			threshold: 10,
+			want:      []string{"9", "d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ClusterMarkers(tt.markers, tt.viewport, tt.zoom, tt.threshold)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var hashes []string
+			for _, c := range got {
+				hashes = append(hashes, c.Hash)
+			}
+			assert.Equal(t, tt.want, hashes)
+		})
+	}
+}
+
+func TestClusterMarkersCentroid(t *testing.T) {
+	markers := []Marker{
+		{Latitude: 37.7749, Longitude: -122.4194, Weight: 1},
+		{Latitude: 37.8044, Longitude: -122.2712, Weight: 3},
+		{Latitude: 40.7128, Longitude: -74.0060},
+		{Latitude: 40.7306, Longitude: -73.9352},
+	}
+	world := BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180}
+
+	got, err := ClusterMarkers(markers, world, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "9", got[0].Hash)
+	assert.Equal(t, 2, got[0].Count)
+	assert.InDelta(t, 4, got[0].Weight, tolerance)
+	assert.InDelta(t, (37.7749+3*37.8044)/4, got[0].Latitude, tolerance)
+	assert.InDelta(t, (-122.4194+3*-122.2712)/4, got[0].Longitude, tolerance)
+
+	assert.Equal(t, "d", got[1].Hash)
+	assert.InDelta(t, (40.7128+40.7306)/2, got[1].Latitude, tolerance)
+	assert.InDelta(t, (-74.0060+-73.9352)/2, got[1].Longitude, tolerance)
+	assert.Equal(t, BBox{MinLatitude: 0, MaxLatitude: 45, MinLongitude: -90, MaxLongitude: -45}, got[1].BBox)
+}
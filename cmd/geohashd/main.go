@@ -0,0 +1,33 @@
+// Command geohashd serves the geohash package over HTTP.
+//
+// Usage:
+//
+//	geohashd [-addr :8080] [-max-cover-cells 10000]
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aoliveti/geohash/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	maxCoverCells := flag.Int("max-cover-cells", server.DefaultMaxCoverCells, "largest number of cells returned by /cover")
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.NewHandler(server.WithMaxCoverCells(*maxCoverCells)),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       time.Minute,
+	}
+
+	log.Printf("geohashd listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
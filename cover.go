@@ -0,0 +1,208 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// maxCoverCells bounds the number of cells Cover is allowed to generate.
+const maxCoverCells = 1 << 20
+
+var (
+	// ErrInvalidBBox is returned when a bounding box has its minimum latitude above its maximum latitude.
+	ErrInvalidBBox = errors.New("invalid bounding box")
+
+	// ErrCoverTooLarge is returned when covering a bounding box would require too many cells at the requested precision.
+	ErrCoverTooLarge = errors.New("cover too large")
+)
+
+// Cover returns the sorted GeoHash cells at the given precision that intersect the bounding box.
+// A box whose MinLongitude is greater than its MaxLongitude is treated as crossing the antimeridian.
+// Returns an error if the box or precision is invalid, or if the covering would be too large.
+func Cover(bbox BBox, precision Precision) ([]string, error) {
+	grid, err := newCoverGrid(bbox, precision)
+	if err != nil {
+		return nil, err
+	}
+	if grid.size() > maxCoverCells {
+		return nil, ErrCoverTooLarge
+	}
+
+	hashes := make([]string, 0, grid.size())
+	for _, c := range grid.columns {
+		for lng := c.lo; lng <= c.hi; lng++ {
+			for lat := grid.rows.lo; lat <= grid.rows.hi; lat++ {
+				hashes = append(hashes, encodeToBase32(interlaceBitsets(lat, lng, precision), precision))
+			}
+		}
+	}
+
+	sort.Strings(hashes)
+	return dedupSorted(hashes), nil
+}
+
+// MustCover returns the GeoHash cells covering the bounding box or panics if an error occurs.
+func MustCover(bbox BBox, precision Precision) []string {
+	hashes, err := Cover(bbox, precision)
+	if err != nil {
+		panic(err)
+	}
+	return hashes
+}
+
+// CoverSize returns the number of cells Cover returns for the bounding box at the given precision, without
+// generating them, so that callers can bound the size of a covering before requesting it.
+// Returns an error if the box or precision is invalid.
+func CoverSize(bbox BBox, precision Precision) (uint64, error) {
+	grid, err := newCoverGrid(bbox, precision)
+	if err != nil {
+		return 0, err
+	}
+
+	// The two column ranges of a box crossing the antimeridian overlap when it spans the whole globe.
+	lngBits := int(precision)*bitsPerChar - int(precision)*bitsPerChar/2
+	columns := uint64(0)
+	for _, c := range grid.columns {
+		columns += c.hi - c.lo + 1
+	}
+	columns = min(columns, uint64(1)<<lngBits)
+	return columns * (grid.rows.hi - grid.rows.lo + 1), nil
+}
+
+// Compact normalizes a set of GeoHash cells into an equivalent minimal sorted covering: duplicates and cells
+// contained in another cell of the set are removed, and every complete group of 32 siblings is replaced by
+// its parent. Returns an error if any hash is invalid.
+func Compact(hashes []string) ([]string, error) {
+	s, err := NewSet(hashes...)
+	if err != nil {
+		return nil, err
+	}
+	return s.Hashes(), nil
+}
+
+// MustCompact normalizes a set of GeoHash cells or panics if an error occurs.
+func MustCompact(hashes []string) []string {
+	result, err := Compact(hashes)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// dedupSorted removes consecutive duplicates from a sorted slice in place.
+func dedupSorted(hashes []string) []string {
+	result := hashes[:0]
+	for i, hash := range hashes {
+		if i > 0 && hash == hashes[i-1] {
+			continue
+		}
+		result = append(result, hash)
+	}
+	return result
+}
+
+type (
+	// gridInterval is an inclusive range of grid indexes along one axis.
+	gridInterval struct {
+		lo, hi uint64
+	}
+
+	// coverGrid is the set of grid rows and columns intersecting a bounding box at a given precision.
+	coverGrid struct {
+		rows    gridInterval
+		columns []gridInterval
+	}
+)
+
+// newCoverGrid computes the grid rows and columns intersecting the bounding box at the given precision.
+func newCoverGrid(bbox BBox, precision Precision) (coverGrid, error) {
+	if err := validateBBox(bbox); err != nil {
+		return coverGrid{}, err
+	}
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return coverGrid{}, err
+	}
+
+	totalBits := int(precision) * bitsPerChar
+	latBits := totalBits / 2
+	lngBits := totalBits - latBits
+
+	var grid coverGrid
+	grid.rows.lo, grid.rows.hi = gridSpan(bbox.MinLatitude, bbox.MaxLatitude, minLatitude, maxLatitude, latBits)
+
+	if bbox.MinLongitude <= bbox.MaxLongitude {
+		lo, hi := gridSpan(bbox.MinLongitude, bbox.MaxLongitude, minLongitude, maxLongitude, lngBits)
+		grid.columns = []gridInterval{{lo, hi}}
+		return grid, nil
+	}
+
+	lo, _ := gridSpan(bbox.MinLongitude, maxLongitude, minLongitude, maxLongitude, lngBits)
+	_, hi := gridSpan(minLongitude, bbox.MaxLongitude, minLongitude, maxLongitude, lngBits)
+	grid.columns = []gridInterval{{0, hi}, {lo, uint64(1)<<lngBits - 1}}
+	return grid, nil
+}
+
+// size returns an upper bound of the number of cells in the grid.
+func (g coverGrid) size() uint64 {
+	columns := uint64(0)
+	for _, c := range g.columns {
+		columns += c.hi - c.lo + 1
+	}
+	return columns * (g.rows.hi - g.rows.lo + 1)
+}
+
+// gridSpan returns the inclusive range of grid indexes at the given bit depth touched by [from, to].
+// A value lying exactly on the upper edge of a cell does not pull in the next cell.
+func gridSpan(from, to, lowerBound, upperBound float64, bits int) (lo, hi uint64) {
+	cells := float64(uint64(1) << bits)
+	scale := cells / (upperBound - lowerBound)
+	last := uint64(1)<<bits - 1
+
+	start := math.Floor((from - lowerBound) * scale)
+	end := math.Ceil((to-lowerBound)*scale) - 1
+	if end < start {
+		end = start
+	}
+
+	return min(uint64(start), last), min(uint64(end), last)
+}
+
+// validateBBox reports whether the bounding box has coordinates within the valid ranges.
+func validateBBox(bbox BBox) error {
+	if err := checkCoordinates(bbox.MinLatitude, bbox.MinLongitude); err != nil {
+		return err
+	}
+	if err := checkCoordinates(bbox.MaxLatitude, bbox.MaxLongitude); err != nil {
+		return err
+	}
+	if bbox.MinLatitude > bbox.MaxLatitude {
+		return ErrInvalidBBox
+	}
+	return nil
+}
+
+// validateHash reports whether the hash has a valid length and only contains Base32 GeoHash characters.
+func validateHash(hash string) error {
+	if err := checkHashLength(len(hash), SubPoint); err != nil {
+		return err
+	}
+	for i, char := range hash {
+		if _, ok := StdEncoding.value(char); !ok {
+			return &FormatError{Position: i, Char: char}
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the coordinates lie within the bounding box, edges included.
+// A box whose MinLongitude is greater than its MaxLongitude is treated as crossing the antimeridian.
+func (b BBox) Contains(latitude, longitude float64) bool {
+	if latitude < b.MinLatitude || latitude > b.MaxLatitude {
+		return false
+	}
+	if b.MinLongitude <= b.MaxLongitude {
+		return longitude >= b.MinLongitude && longitude <= b.MaxLongitude
+	}
+	return longitude >= b.MinLongitude || longitude <= b.MaxLongitude
+}
@@ -0,0 +1,260 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCover(t *testing.T) {
+	tests := []struct {
+		name      string
+		bbox      BBox
+		precision Precision
+		want      []string
+		wantErr   error
+	}{
+		{
+			name:      "Invalid bbox - latitude out of range",
+			bbox:      BBox{MinLatitude: -91, MaxLatitude: 0, MinLongitude: 0, MaxLongitude: 1},
+			precision: City,
+			wantErr:   ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Invalid bbox - longitude out of range",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 1, MinLongitude: 0, MaxLongitude: 181},
+			precision: City,
+			wantErr:   ErrLongitudeOutOfRange,
+		},
+		{
+			name:      "Invalid bbox - inverted latitudes",
+			bbox:      BBox{MinLatitude: 10, MaxLatitude: 0, MinLongitude: 0, MaxLongitude: 1},
+			precision: City,
+			wantErr:   ErrInvalidBBox,
+		},
+		{
+			name:      "Invalid precision",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 1, MinLongitude: 0, MaxLongitude: 1},
+			precision: 13,
+			wantErr:   ErrPrecisionOutOfRange,
+		},
+		{
+			name:      "Too many cells",
+			bbox:      BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+			precision: Street,
+			wantErr:   ErrCoverTooLarge,
+		},
+		{
+			name:      "Exact cell bounds",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 45, MinLongitude: -135, MaxLongitude: -90},
+			precision: Global,
+			want:      []string{"9"},
+		},
+		{
+			name:      "Single point",
+			bbox:      BBox{MinLatitude: 37.7749, MaxLatitude: 37.7749, MinLongitude: -122.4194, MaxLongitude: -122.4194},
+			precision: City,
+			want:      []string{"9q8yy"},
+		},
+		{
+			name:      "Neighborhood of a city cell",
+			bbox:      BBox{MinLatitude: 37.75, MaxLatitude: 37.8, MinLongitude: -122.42, MaxLongitude: -122.38},
+			precision: City,
+			want:      []string{"9q8yy", "9q8yz", "9q8zn", "9q8zp"},
+		},
+		{
+			name:      "Crossing the antimeridian",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 170, MaxLongitude: -170},
+			precision: Global,
+			want:      []string{"8", "x"},
+		},
+		{
+			name:      "Whole world",
+			bbox:      BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+			precision: Global,
+			want: []string{
+				"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "b", "c", "d", "e", "f", "g",
+				"h", "j", "k", "m", "n", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Cover(tt.bbox, tt.precision)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMustCover(t *testing.T) {
+	assert.Panics(t, func() { MustCover(BBox{MinLatitude: 1}, City) })
+	assert.Equal(t, []string{"9q8yy"}, MustCover(BBox{
+		MinLatitude: 37.7749, MaxLatitude: 37.7749, MinLongitude: -122.4194, MaxLongitude: -122.4194,
+	}, City))
+}
+
+func TestCoverSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		bbox      BBox
+		precision Precision
+		want      uint64
+		wantErr   error
+	}{
+		{
+			name:      "Invalid bbox",
+			bbox:      BBox{MinLatitude: 10, MaxLatitude: 0, MinLongitude: 0, MaxLongitude: 1},
+			precision: City,
+			wantErr:   ErrInvalidBBox,
+		},
+		{
+			name:      "Invalid precision",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 1, MinLongitude: 0, MaxLongitude: 1},
+			precision: 13,
+			wantErr:   ErrPrecisionOutOfRange,
+		},
+		{
+			name:      "Single cell",
+			bbox:      BBox{MinLatitude: 37.7749, MaxLatitude: 37.7749, MinLongitude: -122.4194, MaxLongitude: -122.4194},
+			precision: City,
+			want:      1,
+		},
+		{
+			name:      "Whole globe",
+			bbox:      BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+			precision: Country,
+			want:      1024,
+		},
+		{
+			name:      "Crossing the antimeridian around the whole globe",
+			bbox:      BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: 10, MaxLongitude: 9.99},
+			precision: Country,
+			want:      1024,
+		},
+		{
+			name:      "Beyond the limit of Cover",
+			bbox:      BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+			precision: Street,
+			want:      1 << 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CoverSize(tt.bbox, tt.precision)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+
+			if hashes, err := Cover(tt.bbox, tt.precision); err == nil {
+				assert.Len(t, hashes, int(got))
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	children := make([]string, 0, len(alphabet))
+	for _, c := range alphabet {
+		children = append(children, "9q8"+string(c))
+	}
+
+	tests := []struct {
+		name    string
+		hashes  []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Invalid hash - too long",
+			hashes:  []string{"9q8yyk8ytpxrs"},
+			wantErr: ErrInvalidHashLength,
+		},
+		{
+			name:    "Invalid hash - invalid characters",
+			hashes:  []string{"9q8yy!"},
+			wantErr: ErrInvalidHashFormat,
+		},
+		{
+			name:   "Duplicates and contained cells",
+			hashes: []string{"9q8yy", "9q8", "9q8", "dr5", "9q8zz"},
+			want:   []string{"9q8", "dr5"},
+		},
+		{
+			name:   "Complete siblings merged into parent",
+			hashes: append([]string{"dr5"}, children...),
+			want:   []string{"9q8", "dr5"},
+		},
+		{
+			name:   "Incomplete siblings kept",
+			hashes: children[1:],
+			want:   children[1:],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compact(tt.hashes)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMustCompact(t *testing.T) {
+	assert.Panics(t, func() { MustCompact([]string{""}) })
+	assert.Equal(t, []string{"9q"}, MustCompact([]string{"9q8", "9q"}))
+}
+
+func TestBBoxContains(t *testing.T) {
+	tests := []struct {
+		name      string
+		bbox      BBox
+		latitude  float64
+		longitude float64
+		want      bool
+	}{
+		{
+			name:      "Inside",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 0, MaxLongitude: 10},
+			latitude:  5,
+			longitude: 5,
+			want:      true,
+		},
+		{
+			name:      "On the edge",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 0, MaxLongitude: 10},
+			latitude:  10,
+			longitude: 0,
+			want:      true,
+		},
+		{
+			name:      "Outside",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 0, MaxLongitude: 10},
+			latitude:  5,
+			longitude: 11,
+			want:      false,
+		},
+		{
+			name:      "Crossing the antimeridian - inside",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 170, MaxLongitude: -170},
+			latitude:  5,
+			longitude: -175,
+			want:      true,
+		},
+		{
+			name:      "Crossing the antimeridian - outside",
+			bbox:      BBox{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 170, MaxLongitude: -170},
+			latitude:  5,
+			longitude: 0,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.bbox.Contains(tt.latitude, tt.longitude))
+		})
+	}
+}
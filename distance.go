@@ -0,0 +1,64 @@
+package geohash
+
+import "math"
+
+// earthRadius is the mean Earth radius in meters (IUGG).
+const earthRadius = 6371008.8
+
+// Distance returns the great-circle distance in meters between two coordinates using the haversine formula.
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	phi1 := degToRad(lat1)
+	phi2 := degToRad(lat2)
+	dPhi := degToRad(lat2 - lat1)
+	dLambda := degToRad(lng2 - lng1)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// RadiusBBox returns the smallest bounding box containing every point within radius meters of the given
+// coordinates. The box crosses the antimeridian (MinLongitude > MaxLongitude) when the circle does.
+// Returns an error if the coordinates are out of range or the radius is invalid.
+func RadiusBBox(latitude, longitude, radius float64) (BBox, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return BBox{}, err
+	}
+	if radius < 0 || math.IsNaN(radius) || math.IsInf(radius, 0) {
+		return BBox{}, ErrInvalidRadius
+	}
+
+	angle := radius / earthRadius
+	latDelta := radToDeg(angle)
+
+	bbox := BBox{
+		MinLatitude:  math.Max(minLatitude, latitude-latDelta),
+		MaxLatitude:  math.Min(maxLatitude, latitude+latDelta),
+		MinLongitude: minLongitude,
+		MaxLongitude: maxLongitude,
+	}
+
+	// Circles reaching a pole span every longitude.
+	if latitude+latDelta >= maxLatitude || latitude-latDelta <= minLatitude {
+		return bbox, nil
+	}
+
+	lngDelta := radToDeg(math.Asin(math.Sin(angle) / math.Cos(degToRad(latitude))))
+	if lngDelta >= maxLongitude {
+		return bbox, nil
+	}
+
+	bbox.MinLongitude = wrapLongitude(longitude - lngDelta)
+	bbox.MaxLongitude = wrapLongitude(longitude + lngDelta)
+	return bbox, nil
+}
+
+// wrapLongitude brings a longitude within [-180, 180], leaving values already in range untouched.
+func wrapLongitude(lng float64) float64 {
+	if lng >= minLongitude && lng <= maxLongitude {
+		return lng
+	}
+	_, lng = wrapCoordinates(0, lng)
+	return lng
+}
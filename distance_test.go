@@ -0,0 +1,98 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want                   float64
+		delta                  float64
+	}{
+		{
+			name: "Same point",
+			lat1: 37.7749, lng1: -122.4194, lat2: 37.7749, lng2: -122.4194,
+			want: 0, delta: 1e-9,
+		},
+		{
+			name: "San Francisco to New York",
+			lat1: 37.7749, lng1: -122.4194, lat2: 40.7128, lng2: -74.0060,
+			want: 4129086, delta: 1000,
+		},
+		{
+			name: "Across the antimeridian",
+			lat1: 0, lng1: 179.5, lat2: 0, lng2: -179.5,
+			want: 111195, delta: 10,
+		},
+		{
+			name: "Pole to pole",
+			lat1: 90, lng1: 0, lat2: -90, lng2: 0,
+			want: 20015115, delta: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, Distance(tt.lat1, tt.lng1, tt.lat2, tt.lng2), tt.delta)
+		})
+	}
+}
+
+func TestRadiusBBox(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		radius    float64
+		want      BBox
+		wantErr   error
+	}{
+		{
+			name:     "Latitude out of range",
+			latitude: 91,
+			wantErr:  ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Longitude out of range",
+			longitude: -181,
+			wantErr:   ErrLongitudeOutOfRange,
+		},
+		{
+			name:    "Invalid radius",
+			radius:  -1,
+			wantErr: ErrInvalidRadius,
+		},
+		{
+			name:   "Equator - 1 degree",
+			radius: 111195.08,
+			want:   BBox{MinLatitude: -1, MaxLatitude: 1, MinLongitude: -1, MaxLongitude: 1},
+		},
+		{
+			name:      "Crossing the antimeridian",
+			longitude: 179.5,
+			radius:    111195.08,
+			want:      BBox{MinLatitude: -1, MaxLatitude: 1, MinLongitude: 178.5, MaxLongitude: -179.5},
+		},
+		{
+			name:     "Reaching the pole",
+			latitude: 89.5,
+			radius:   111195.08,
+			want:     BBox{MinLatitude: 88.5, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RadiusBBox(tt.latitude, tt.longitude, tt.radius)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.InDelta(t, tt.want.MinLatitude, got.MinLatitude, tolerance)
+			assert.InDelta(t, tt.want.MaxLatitude, got.MaxLatitude, tolerance)
+			assert.InDelta(t, tt.want.MinLongitude, got.MinLongitude, tolerance)
+			assert.InDelta(t, tt.want.MaxLongitude, got.MaxLongitude, tolerance)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package geohash
+
+import (
+	"errors"
+	"math/bits"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidAlphabet is returned when an alphabet does not have a power-of-two number of distinct ASCII characters.
+var ErrInvalidAlphabet = errors.New("invalid alphabet")
+
+type (
+	// Codec converts coordinates to hash strings and back. Encoding and Geohash36 implement it, so that code
+	// can work against any configured scheme.
+	Codec interface {
+		// MaxPrecision returns the longest hash supported, in characters.
+		MaxPrecision() Precision
+		// Encode generates the hash of the given coordinates with precision characters.
+		Encode(latitude, longitude float64, precision Precision) (string, error)
+		// Decode returns the center coordinates of a hash.
+		Decode(hash string) (latitude, longitude float64, err error)
+		// DecodeBBox returns the center coordinates and bounding box of a hash.
+		DecodeBBox(hash string) (latitude, longitude float64, bbox BBox, err error)
+		// Neighbor returns the neighbor of a hash in the given direction.
+		Neighbor(hash string, direction Direction) (string, error)
+		// Neighbors returns the eight neighbors of a hash, indexed by Direction.
+		Neighbors(hash string) ([]string, error)
+	}
+
+	// Encoding is a GeoHash string encoding: an alphabet whose characters each carry the same number of bits.
+	// The alphabet length must be a power of two from 2 to 64; see Geohash36 for the base-36 scheme.
+	Encoding struct {
+		alphabet    string
+		bitsPerChar int
+		decodeMap   [256]int8
+	}
+)
+
+var (
+	// StdEncoding is the standard lower-case base32 GeoHash encoding used by Encode and Decode.
+	StdEncoding = MustNewEncoding(alphabet)
+
+	// UpperEncoding is the upper-case variant of the standard encoding.
+	UpperEncoding = MustNewEncoding(strings.ToUpper(alphabet))
+
+	// QuadEncoding is a base-4 encoding where each character holds one longitude and one latitude bit.
+	QuadEncoding = MustNewEncoding("0123")
+)
+
+// NewEncoding returns the Encoding defined by the given alphabet, in increasing order of value.
+// Returns an error if the alphabet length is not a power of two between 2 and 64, or if it contains
+// repeated or non-ASCII characters.
+func NewEncoding(alphabet string) (*Encoding, error) {
+	n := len(alphabet)
+	if n < 2 || n > 64 || n&(n-1) != 0 {
+		return nil, ErrInvalidAlphabet
+	}
+
+	e := &Encoding{alphabet: alphabet, bitsPerChar: bits.TrailingZeros(uint(n))}
+	for i := range e.decodeMap {
+		e.decodeMap[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		c := alphabet[i]
+		if c >= 0x80 || e.decodeMap[c] >= 0 {
+			return nil, ErrInvalidAlphabet
+		}
+		e.decodeMap[c] = int8(i)
+	}
+	return e, nil
+}
+
+// MustNewEncoding returns the Encoding defined by the given alphabet or panics if an error occurs.
+func MustNewEncoding(alphabet string) *Encoding {
+	e, err := NewEncoding(alphabet)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// BitsPerChar returns the number of bits carried by each character.
+func (e *Encoding) BitsPerChar() int {
+	return e.bitsPerChar
+}
+
+// MaxPrecision returns the longest hash supported by the encoding, in characters.
+func (e *Encoding) MaxPrecision() Precision {
+	return Precision(maxHash128Bits / e.bitsPerChar)
+}
+
+// Encode generates the hash of the given coordinates with precision characters of this encoding.
+// Returns an error if the latitude, longitude, or precision is out of the valid range.
+func (e *Encoding) Encode(latitude, longitude float64, precision Precision) (string, error) {
+	if err := checkPrecision(int(precision), int(Global), int(e.MaxPrecision())); err != nil {
+		return "", err
+	}
+	h, err := EncodeHash128(latitude, longitude, int(precision)*e.bitsPerChar)
+	if err != nil {
+		return "", err
+	}
+	return e.format(h), nil
+}
+
+// Decode returns the center coordinates of a hash of this encoding.
+// Returns an error if the hash is invalid.
+func (e *Encoding) Decode(hash string) (latitude, longitude float64, err error) {
+	h, err := e.parse(hash)
+	if err != nil {
+		return 0, 0, err
+	}
+	latitude, longitude, _ = h.Decode()
+	return latitude, longitude, nil
+}
+
+// DecodeBBox returns the center coordinates and bounding box of a hash of this encoding.
+// Returns an error if the hash is invalid.
+func (e *Encoding) DecodeBBox(hash string) (latitude, longitude float64, bbox BBox, err error) {
+	h, err := e.parse(hash)
+	if err != nil {
+		return 0, 0, BBox{}, err
+	}
+	latitude, longitude, bbox = h.Decode()
+	return latitude, longitude, bbox, nil
+}
+
+// Neighbor returns the neighbor of a hash of this encoding in the given direction.
+// Returns an error if the hash or direction is invalid.
+func (e *Encoding) Neighbor(hash string, direction Direction) (string, error) {
+	h, err := e.parse(hash)
+	if err != nil {
+		return "", err
+	}
+	if direction < N || direction > NW {
+		return "", ErrDirectionOutOfRange
+	}
+
+	d := directionOffsets[direction]
+	return e.format(h.neighbor(d.lat, d.lng)), nil
+}
+
+// Neighbors returns the eight neighbors of a hash of this encoding, indexed by Direction.
+// Returns an error if the hash is invalid.
+func (e *Encoding) Neighbors(hash string) ([]string, error) {
+	h, err := e.parse(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]string, len(directionOffsets))
+	for dir, d := range directionOffsets {
+		results[dir] = e.format(h.neighbor(d.lat, d.lng))
+	}
+	return results, nil
+}
+
+// value returns the value of a character of the encoding, or false if the character is not in the alphabet.
+func (e *Encoding) value(char rune) (uint64, bool) {
+	if char < 0 || char >= utf8.RuneSelf || e.decodeMap[char] < 0 {
+		return 0, false
+	}
+	return uint64(e.decodeMap[char]), true
+}
+
+// format returns the string of a bitset, dropping trailing bits that do not fill a whole character.
+func (e *Encoding) format(h Hash128) string {
+	n := h.Bits / e.bitsPerChar
+	mask := uint64(1)<<e.bitsPerChar - 1
+
+	var sb strings.Builder
+	sb.Grow(n)
+	for i := 0; i < n; i++ {
+		_, lo := shiftRight128(h.Hi, h.Lo, uint(h.Bits-(i+1)*e.bitsPerChar))
+		sb.WriteByte(e.alphabet[lo&mask])
+	}
+	return sb.String()
+}
+
+// parse returns the bitset of a hash of this encoding.
+func (e *Encoding) parse(hash string) (Hash128, error) {
+	if err := checkHashLength(len(hash), e.MaxPrecision()); err != nil {
+		return Hash128{}, err
+	}
+
+	h := Hash128{Bits: len(hash) * e.bitsPerChar}
+	for i := 0; i < len(hash); i++ {
+		value := e.decodeMap[hash[i]]
+		if value < 0 {
+			char, _ := utf8.DecodeRuneInString(hash[i:])
+			return Hash128{}, &FormatError{Position: i, Char: char}
+		}
+		h.Hi, h.Lo = shiftLeft128(h.Hi, h.Lo, uint(e.bitsPerChar))
+		h.Lo |= uint64(value)
+	}
+	return h, nil
+}
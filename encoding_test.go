@@ -0,0 +1,144 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEncoding(t *testing.T) {
+	tests := []struct {
+		name        string
+		alphabet    string
+		wantBits    int
+		wantErr     error
+		wantMaxPrec Precision
+	}{
+		{name: "Binary", alphabet: "01", wantBits: 1, wantMaxPrec: 128},
+		{name: "Quad", alphabet: "0123", wantBits: 2, wantMaxPrec: 64},
+		{name: "Base32", alphabet: alphabet, wantBits: 5, wantMaxPrec: MaxPrecision},
+		{name: "Too short", alphabet: "0", wantErr: ErrInvalidAlphabet},
+		{name: "Base-36", alphabet: "23456789bBCdDFgGhHjJKlLMnNPqQrRtTVWX", wantErr: ErrInvalidAlphabet},
+		{name: "Repeated character", alphabet: "0120", wantErr: ErrInvalidAlphabet},
+		{name: "Non-ASCII character", alphabet: "01\xc3\xa9", wantErr: ErrInvalidAlphabet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewEncoding(tt.alphabet)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				assert.Panics(t, func() { MustNewEncoding(tt.alphabet) })
+				return
+			}
+			assert.Equal(t, tt.wantBits, got.BitsPerChar())
+			assert.Equal(t, tt.wantMaxPrec, got.MaxPrecision())
+		})
+	}
+}
+
+func TestEncodingEncode(t *testing.T) {
+	tests := []struct {
+		name      string
+		encoding  *Encoding
+		precision Precision
+		want      string
+		wantErr   error
+	}{
+		{name: "Standard", encoding: StdEncoding, precision: City, want: "9q8yy"},
+		{name: "Upper-case", encoding: UpperEncoding, precision: City, want: "9Q8YY"},
+		{name: "Quad", encoding: QuadEncoding, precision: 10, want: "1031210132"},
+		{name: "Precision too low", encoding: QuadEncoding, precision: 0, wantErr: ErrPrecisionOutOfRange},
+		{name: "Precision too high", encoding: QuadEncoding, precision: 65, wantErr: ErrPrecisionOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.encoding.Encode(37.7749, -122.4194, tt.precision)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := QuadEncoding.Encode(91, 0, 4)
+	assert.ErrorIs(t, err, ErrLatitudeOutOfRange)
+}
+
+func TestEncodingMatchesStandard(t *testing.T) {
+	for precision := Global; precision <= MaxPrecision; precision++ {
+		want := MustEncode(-33.8568, 151.2153, precision)
+		got, err := StdEncoding.Encode(-33.8568, 151.2153, precision)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+
+		_, _, wantBBox := MustDecodeBBox(want)
+		_, _, bbox, err := StdEncoding.DecodeBBox(got)
+		require.NoError(t, err)
+		assert.Equal(t, wantBBox, bbox)
+
+		neighbors, err := StdEncoding.Neighbors(got)
+		require.NoError(t, err)
+		assert.Equal(t, MustNeighbors(want), neighbors)
+	}
+}
+
+func TestEncodingDecode(t *testing.T) {
+	lat, lng, bbox, err := QuadEncoding.DecodeBBox("03")
+	require.NoError(t, err)
+	assert.Equal(t, BBox{MinLatitude: -45, MaxLatitude: 0, MinLongitude: -90, MaxLongitude: 0}, bbox)
+	assert.InDelta(t, -22.5, lat, tolerance)
+	assert.InDelta(t, -45, lng, tolerance)
+
+	lat, lng, err = UpperEncoding.Decode("9Q8YY")
+	require.NoError(t, err)
+	wantLat, wantLng := MustDecode("9q8yy")
+	assert.InDelta(t, wantLat, lat, tolerance)
+	assert.InDelta(t, wantLng, lng, tolerance)
+
+	_, _, err = UpperEncoding.Decode("9q8yy")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+	_, _, err = QuadEncoding.Decode("")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+	_, _, _, err = QuadEncoding.DecodeBBox("0124")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+}
+
+func TestEncodingNeighbor(t *testing.T) {
+	got, err := QuadEncoding.Neighbors("0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3", "2", "3", "1", "3", "2", "3"}, got)
+
+	n, err := UpperEncoding.Neighbor("9Q8YY", N)
+	require.NoError(t, err)
+	assert.Equal(t, "9Q8ZN", n)
+
+	_, err = QuadEncoding.Neighbor("0", 8)
+	assert.ErrorIs(t, err, ErrDirectionOutOfRange)
+	_, err = QuadEncoding.Neighbors("4")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+}
+
+func TestCodec(t *testing.T) {
+	for _, codec := range []Codec{StdEncoding, QuadEncoding, Geohash36} {
+		hash, err := codec.Encode(37.7749, -122.4194, 6)
+		require.NoError(t, err)
+
+		lat, lng, bbox, err := codec.DecodeBBox(hash)
+		require.NoError(t, err)
+		assert.True(t, bbox.Contains(37.7749, -122.4194))
+
+		neighbor, err := codec.Neighbor(hash, N)
+		require.NoError(t, err)
+		_, _, above, err := codec.DecodeBBox(neighbor)
+		require.NoError(t, err)
+		assert.InDelta(t, bbox.MaxLatitude, above.MinLatitude, 1e-9)
+
+		centerLat, centerLng, err := codec.Decode(hash)
+		require.NoError(t, err)
+		assert.Equal(t, lat, centerLat)
+		assert.Equal(t, lng, centerLng)
+		assert.GreaterOrEqual(t, codec.MaxPrecision(), Precision(6))
+	}
+}
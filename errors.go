@@ -0,0 +1,157 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+)
+
+// RangeError reports a coordinate outside its valid range.
+// It matches ErrLatitudeOutOfRange or ErrLongitudeOutOfRange with errors.Is.
+type RangeError struct {
+	// Err is ErrLatitudeOutOfRange or ErrLongitudeOutOfRange.
+	Err error
+	// Value is the offending coordinate.
+	Value float64
+	// Min and Max are the inclusive bounds of the valid range.
+	Min float64
+	Max float64
+}
+
+// Error implements the error interface.
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("%v: %g not in [%g, %g]", e.Err, e.Value, e.Min, e.Max)
+}
+
+// Unwrap returns the sentinel error of the coordinate.
+func (e *RangeError) Unwrap() error {
+	return e.Err
+}
+
+// PrecisionError reports a precision, in characters or bits, outside its valid range.
+// It matches ErrPrecisionOutOfRange with errors.Is.
+type PrecisionError struct {
+	// Value is the requested precision.
+	Value int
+	// Min and Max are the inclusive bounds of the valid range.
+	Min int
+	Max int
+}
+
+// Error implements the error interface.
+func (e *PrecisionError) Error() string {
+	return fmt.Sprintf("%v: %d not in [%d, %d]", ErrPrecisionOutOfRange, e.Value, e.Min, e.Max)
+}
+
+// Unwrap returns ErrPrecisionOutOfRange.
+func (e *PrecisionError) Unwrap() error {
+	return ErrPrecisionOutOfRange
+}
+
+// LengthError reports a GeoHash string whose length is outside the valid range.
+// It matches ErrInvalidHashLength with errors.Is.
+type LengthError struct {
+	// Length is the length of the hash.
+	Length int
+	// Min and Max are the inclusive bounds of the valid range.
+	Min int
+	Max int
+}
+
+// Error implements the error interface.
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%v: %d not in [%d, %d]", ErrInvalidHashLength, e.Length, e.Min, e.Max)
+}
+
+// Unwrap returns ErrInvalidHashLength.
+func (e *LengthError) Unwrap() error {
+	return ErrInvalidHashLength
+}
+
+// FormatError reports an invalid character in a GeoHash string. It matches ErrInvalidHashFormat with errors.Is.
+type FormatError struct {
+	// Position is the byte offset of the character in the input.
+	Position int
+	// Char is the offending character.
+	Char rune
+}
+
+// Error implements the error interface.
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("%v: character %q at position %d", ErrInvalidHashFormat, e.Char, e.Position)
+}
+
+// Unwrap returns ErrInvalidHashFormat.
+func (e *FormatError) Unwrap() error {
+	return ErrInvalidHashFormat
+}
+
+// IntError reports an integer GeoHash whose value does not fit in its bit depth.
+// It matches ErrInvalidHashFormat with errors.Is.
+type IntError struct {
+	// Value is the integer GeoHash.
+	Value uint64
+	// Bits is the bit depth of the GeoHash.
+	Bits int
+}
+
+// Error implements the error interface.
+func (e *IntError) Error() string {
+	return fmt.Sprintf("%v: %#x does not fit in %d bits", ErrInvalidHashFormat, e.Value, e.Bits)
+}
+
+// Unwrap returns ErrInvalidHashFormat.
+func (e *IntError) Unwrap() error {
+	return ErrInvalidHashFormat
+}
+
+// checkCoordinates returns a *RangeError if the coordinates are outside the valid WGS84 ranges,
+// or an error wrapping ErrInvalidCoordinate if either is NaN or infinite.
+func checkCoordinates(latitude, longitude float64) error {
+	if err := checkLatitude(latitude, minLatitude, maxLatitude); err != nil {
+		return err
+	}
+	return checkLongitude(longitude)
+}
+
+// checkLatitude returns a *RangeError if the latitude is outside [lower, upper].
+func checkLatitude(latitude, lower, upper float64) error {
+	if !isFinite(latitude) {
+		return fmt.Errorf("%w: latitude is %v", ErrInvalidCoordinate, latitude)
+	}
+	if latitude < lower || latitude > upper {
+		return &RangeError{Err: ErrLatitudeOutOfRange, Value: latitude, Min: lower, Max: upper}
+	}
+	return nil
+}
+
+// checkLongitude returns a *RangeError if the longitude is outside the valid range.
+func checkLongitude(longitude float64) error {
+	if !isFinite(longitude) {
+		return fmt.Errorf("%w: longitude is %v", ErrInvalidCoordinate, longitude)
+	}
+	if longitude < minLongitude || longitude > maxLongitude {
+		return &RangeError{Err: ErrLongitudeOutOfRange, Value: longitude, Min: minLongitude, Max: maxLongitude}
+	}
+	return nil
+}
+
+// checkPrecision returns a *PrecisionError if the precision is outside [lower, upper].
+func checkPrecision(precision, lower, upper int) error {
+	if precision < lower || precision > upper {
+		return &PrecisionError{Value: precision, Min: lower, Max: upper}
+	}
+	return nil
+}
+
+// checkHashLength returns a *LengthError if a hash of the given length is empty or longer than upper.
+func checkHashLength(length int, upper Precision) error {
+	if length < int(Global) || length > int(upper) {
+		return &LengthError{Length: length, Min: int(Global), Max: int(upper)}
+	}
+	return nil
+}
+
+// isFinite reports whether v is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,159 @@
+package geohash
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       func() error
+		wantErr   error
+		want      *RangeError
+		wantError string
+	}{
+		{
+			name:      "Latitude",
+			err:       func() error { _, err := Encode(91, 0, City); return err },
+			wantErr:   ErrLatitudeOutOfRange,
+			want:      &RangeError{Err: ErrLatitudeOutOfRange, Value: 91, Min: -90, Max: 90},
+			wantError: "latitude out of range: 91 not in [-90, 90]",
+		},
+		{
+			name:      "Longitude",
+			err:       func() error { _, err := Encode(0, -180.5, City); return err },
+			wantErr:   ErrLongitudeOutOfRange,
+			want:      &RangeError{Err: ErrLongitudeOutOfRange, Value: -180.5, Min: -180, Max: 180},
+			wantError: "longitude out of range: -180.5 not in [-180, 180]",
+		},
+		{
+			name:      "Redis latitude",
+			err:       func() error { _, err := EncodeRedis(86, 0); return err },
+			wantErr:   ErrLatitudeOutOfRange,
+			want:      &RangeError{Err: ErrLatitudeOutOfRange, Value: 86, Min: RedisMinLatitude, Max: RedisMaxLatitude},
+			wantError: "latitude out of range: 86 not in [-85.05112878, 85.05112878]",
+		},
+		{
+			name:      "Bounding box",
+			err:       func() error { _, err := Cover(BBox{MinLatitude: -95, MaxLatitude: 0}, City); return err },
+			wantErr:   ErrLatitudeOutOfRange,
+			want:      &RangeError{Err: ErrLatitudeOutOfRange, Value: -95, Min: -90, Max: 90},
+			wantError: "latitude out of range: -95 not in [-90, 90]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			assert.ErrorIs(t, err, tt.wantErr)
+			var rangeErr *RangeError
+			require.True(t, errors.As(err, &rangeErr))
+			assert.Equal(t, tt.want, rangeErr)
+			assert.EqualError(t, err, tt.wantError)
+		})
+	}
+}
+
+func TestPrecisionError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       func() error
+		want      *PrecisionError
+		wantError string
+	}{
+		{
+			name:      "Encode",
+			err:       func() error { _, err := Encode(0, 0, 26); return err },
+			want:      &PrecisionError{Value: 26, Min: 1, Max: 25},
+			wantError: "precision out of range: 26 not in [1, 25]",
+		},
+		{
+			name:      "Cover",
+			err:       func() error { _, err := Cover(BBox{}, 13); return err },
+			want:      &PrecisionError{Value: 13, Min: 1, Max: 12},
+			wantError: "precision out of range: 13 not in [1, 12]",
+		},
+		{
+			name:      "Bits",
+			err:       func() error { _, err := EncodeInt(0, 0, 0); return err },
+			want:      &PrecisionError{Value: 0, Min: 1, Max: 64},
+			wantError: "precision out of range: 0 not in [1, 64]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+			var precisionErr *PrecisionError
+			require.True(t, errors.As(err, &precisionErr))
+			assert.Equal(t, tt.want, precisionErr)
+			assert.EqualError(t, err, tt.wantError)
+		})
+	}
+}
+
+func TestLengthError(t *testing.T) {
+	_, _, err := Decode("")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+	var lengthErr *LengthError
+	require.True(t, errors.As(err, &lengthErr))
+	assert.Equal(t, &LengthError{Length: 0, Min: 1, Max: 25}, lengthErr)
+	assert.EqualError(t, err, "invalid hash length: 0 not in [1, 25]")
+
+	_, err = Compact([]string{"9q8yyk8ytpxrs"})
+	require.True(t, errors.As(err, &lengthErr))
+	assert.Equal(t, &LengthError{Length: 13, Min: 1, Max: 12}, lengthErr)
+}
+
+func TestIntError(t *testing.T) {
+	_, _, _, err := DecodeInt(0x80, 7)
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+	var intErr *IntError
+	require.True(t, errors.As(err, &intErr))
+	assert.Equal(t, &IntError{Value: 0x80, Bits: 7}, intErr)
+	assert.EqualError(t, err, "invalid hash format: 0x80 does not fit in 7 bits")
+}
+
+func TestFormatErrorPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		err  func() error
+		want *FormatError
+	}{
+		{
+			name: "Decode",
+			err:  func() error { _, _, err := Decode("9q8ay"); return err },
+			want: &FormatError{Position: 3, Char: 'a'},
+		},
+		{
+			name: "Decode beyond SubPoint",
+			err:  func() error { _, _, err := Decode("9q8yyk8ytpxrsL"); return err },
+			want: &FormatError{Position: 13, Char: 'L'},
+		},
+		{
+			name: "Neighbor",
+			err:  func() error { _, err := Neighbor("9Q8yy", N); return err },
+			want: &FormatError{Position: 1, Char: 'Q'},
+		},
+		{
+			name: "Encoding",
+			err:  func() error { _, _, err := QuadEncoding.Decode("01é"); return err },
+			want: &FormatError{Position: 2, Char: 'é'},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			assert.ErrorIs(t, err, ErrInvalidHashFormat)
+			var formatErr *FormatError
+			require.True(t, errors.As(err, &formatErr))
+			assert.Equal(t, tt.want, formatErr)
+		})
+	}
+}
@@ -0,0 +1,515 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidPolygon is returned when a polygon has fewer than three vertices or a vertex out of range.
+	ErrInvalidPolygon = errors.New("invalid polygon")
+
+	// ErrDuplicateFence is returned when adding a fence under an identifier that is already in use.
+	ErrDuplicateFence = errors.New("duplicate fence")
+
+	// ErrStaleUpdate is returned when a position update is older than the previous update of the same entity.
+	ErrStaleUpdate = errors.New("stale update")
+)
+
+// Geofence event types.
+const (
+	// GeofenceEnter is emitted when an entity moves into a fence.
+	GeofenceEnter GeofenceEventType = iota
+
+	// GeofenceExit is emitted when an entity leaves a fence.
+	GeofenceExit
+
+	// GeofenceDwell is emitted once when an entity has stayed within a fence for the dwell time.
+	GeofenceDwell
+)
+
+// cellRelation is the position of a cell with respect to a fence.
+type cellRelation int
+
+const (
+	cellOutside cellRelation = iota
+	cellInside
+	cellBoundary
+)
+
+type (
+	// LatLng is a pair of coordinates in degrees.
+	LatLng struct {
+		Latitude  float64
+		Longitude float64
+	}
+
+	// Fence is an area tracked by a Geofencer. It is implemented by Polygon and Circle.
+	Fence interface {
+		// BBox returns the bounding box of the fence.
+		BBox() BBox
+		// Contains reports whether the coordinates lie within the fence.
+		Contains(latitude, longitude float64) bool
+
+		validate() error
+		relate(cell BBox) cellRelation
+	}
+
+	// Polygon is a fence bounded by a ring of vertices, closed implicitly. Edges are straight lines in
+	// latitude and longitude, and the ring must not cross the antimeridian.
+	Polygon []LatLng
+
+	// Circle is a fence holding every point within Radius meters of its center.
+	Circle struct {
+		Latitude  float64
+		Longitude float64
+		Radius    float64
+	}
+
+	// GeofenceEventType identifies the kind of a GeofenceEvent.
+	GeofenceEventType int
+
+	// GeofenceEvent reports a transition of an entity with respect to a fence.
+	GeofenceEvent struct {
+		Type      GeofenceEventType
+		Entity    string
+		Fence     string
+		Time      time.Time
+		Latitude  float64
+		Longitude float64
+	}
+
+	// Geofencer tracks entities against a set of fences and emits events as they move. Fences are indexed by
+	// GeoHash coverings: points in interior cells are accepted without further tests, and only points in
+	// boundary cells, refined down to the geofencer precision, are tested exactly.
+	// It is safe for concurrent use.
+	Geofencer struct {
+		mu        sync.RWMutex
+		precision Precision
+		dwell     time.Duration
+		fences    map[string]*fenceEntry
+		cells     map[string][]fenceCell
+		entities  map[string]*entityState
+	}
+
+	// fenceEntry is an indexed fence along with the cells of its covering.
+	fenceEntry struct {
+		id    string
+		fence Fence
+		cells []string
+	}
+
+	// fenceCell is a cell of the covering of a fence.
+	fenceCell struct {
+		entry    *fenceEntry
+		interior bool
+	}
+
+	// entityState is the last known state of a tracked entity.
+	entityState struct {
+		time     time.Time
+		presence map[string]*fencePresence
+	}
+
+	// fencePresence records when an entity entered a fence and whether its dwell event was emitted.
+	fencePresence struct {
+		since   time.Time
+		dwelled bool
+	}
+)
+
+// String returns the name of the event type.
+func (t GeofenceEventType) String() string {
+	switch t {
+	case GeofenceEnter:
+		return "enter"
+	case GeofenceExit:
+		return "exit"
+	case GeofenceDwell:
+		return "dwell"
+	default:
+		return "unknown"
+	}
+}
+
+// NewGeofencer creates an empty Geofencer whose fence boundaries are refined down to the given precision.
+// A GeofenceDwell event is emitted once an entity has stayed in a fence for dwell; zero disables dwell events.
+// Returns an error if the precision is out of the valid range.
+func NewGeofencer(precision Precision, dwell time.Duration) (*Geofencer, error) {
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return nil, err
+	}
+
+	return &Geofencer{
+		precision: precision,
+		dwell:     dwell,
+		fences:    make(map[string]*fenceEntry),
+		cells:     make(map[string][]fenceCell),
+		entities:  make(map[string]*entityState),
+	}, nil
+}
+
+// Precision returns the precision of the boundary cells of the fences.
+func (g *Geofencer) Precision() Precision {
+	return g.precision
+}
+
+// AddFence indexes a fence under the given identifier.
+// Returns an error if the fence is invalid, the identifier is in use, or the covering would be too large.
+func (g *Geofencer) AddFence(id string, fence Fence) error {
+	if err := fence.validate(); err != nil {
+		return err
+	}
+
+	interior, boundary, err := fenceCover(fence, g.precision)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.fences[id]; ok {
+		return ErrDuplicateFence
+	}
+
+	entry := &fenceEntry{id: id, fence: fence, cells: append(interior, boundary...)}
+	for _, hash := range interior {
+		g.cells[hash] = append(g.cells[hash], fenceCell{entry: entry, interior: true})
+	}
+	for _, hash := range boundary {
+		g.cells[hash] = append(g.cells[hash], fenceCell{entry: entry})
+	}
+	g.fences[id] = entry
+	return nil
+}
+
+// RemoveFence removes the fence with the given identifier and reports whether it was found.
+// Entities within the fence forget about it without an exit event.
+func (g *Geofencer) RemoveFence(id string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	entry, ok := g.fences[id]
+	if !ok {
+		return false
+	}
+
+	for _, hash := range entry.cells {
+		cells := g.cells[hash]
+		for i, c := range cells {
+			if c.entry == entry {
+				cells = append(cells[:i], cells[i+1:]...)
+				break
+			}
+		}
+		if len(cells) == 0 {
+			delete(g.cells, hash)
+		} else {
+			g.cells[hash] = cells
+		}
+	}
+	for _, state := range g.entities {
+		delete(state.presence, id)
+	}
+	delete(g.fences, id)
+	return true
+}
+
+// Fences returns the sorted identifiers of the fences containing the given coordinates.
+// Returns an error if the latitude or longitude is out of range.
+func (g *Geofencer) Fences(latitude, longitude float64) ([]string, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.lookup(latitude, longitude)
+}
+
+// Update records the position of an entity at the given time and returns the resulting events: exits,
+// then enters, then dwells, each ordered by fence identifier. The first update of an entity emits an
+// enter event for every fence containing it.
+// Returns an error if the coordinates are out of range or the update is older than the previous one.
+func (g *Geofencer) Update(entity string, latitude, longitude float64, t time.Time) ([]GeofenceEvent, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state, ok := g.entities[entity]
+	if ok && t.Before(state.time) {
+		return nil, ErrStaleUpdate
+	}
+
+	inside, err := g.lookup(latitude, longitude)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		state = &entityState{presence: make(map[string]*fencePresence)}
+		g.entities[entity] = state
+	}
+	state.time = t
+
+	event := func(typ GeofenceEventType, fence string) GeofenceEvent {
+		return GeofenceEvent{Type: typ, Entity: entity, Fence: fence, Time: t, Latitude: latitude, Longitude: longitude}
+	}
+
+	current := make(map[string]bool, len(inside))
+	for _, id := range inside {
+		current[id] = true
+	}
+
+	var exits []string
+	for id := range state.presence {
+		if !current[id] {
+			exits = append(exits, id)
+		}
+	}
+	sort.Strings(exits)
+
+	var events []GeofenceEvent
+	for _, id := range exits {
+		delete(state.presence, id)
+		events = append(events, event(GeofenceExit, id))
+	}
+
+	var dwells []string
+	for _, id := range inside {
+		p, ok := state.presence[id]
+		if !ok {
+			state.presence[id] = &fencePresence{since: t}
+			events = append(events, event(GeofenceEnter, id))
+			continue
+		}
+		if g.dwell > 0 && !p.dwelled && t.Sub(p.since) >= g.dwell {
+			p.dwelled = true
+			dwells = append(dwells, id)
+		}
+	}
+	for _, id := range dwells {
+		events = append(events, event(GeofenceDwell, id))
+	}
+	return events, nil
+}
+
+// Forget discards the state of an entity without emitting events.
+func (g *Geofencer) Forget(entity string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.entities, entity)
+}
+
+// lookup returns the sorted identifiers of the fences containing the coordinates. The caller must hold the lock.
+func (g *Geofencer) lookup(latitude, longitude float64) ([]string, error) {
+	hash, err := Encode(latitude, longitude, g.precision)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for i := 1; i <= len(hash); i++ {
+		for _, c := range g.cells[hash[:i]] {
+			if c.interior || c.entry.fence.Contains(latitude, longitude) {
+				ids = append(ids, c.entry.id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// fenceCover splits the covering of a fence into interior cells, as coarse as possible, and boundary
+// cells at the given precision.
+func fenceCover(fence Fence, precision Precision) (interior, boundary []string, err error) {
+	roots, err := Cover(fence.BBox(), Global)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var walk func(hash string) error
+	walk = func(hash string) error {
+		_, _, cell, _ := DecodeBBox(hash)
+		switch fence.relate(cell) {
+		case cellInside:
+			interior = append(interior, hash)
+		case cellBoundary:
+			if len(hash) == int(precision) {
+				if len(boundary) == maxCoverCells {
+					return ErrCoverTooLarge
+				}
+				boundary = append(boundary, hash)
+				return nil
+			}
+			for i := 0; i < len(StdEncoding.alphabet); i++ {
+				if err := walk(hash + StdEncoding.alphabet[i:i+1]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, root := range roots {
+		if err := walk(root); err != nil {
+			return nil, nil, err
+		}
+	}
+	return interior, boundary, nil
+}
+
+// BBox returns the bounding box of the polygon vertices.
+func (p Polygon) BBox() BBox {
+	bbox := BBox{
+		MinLatitude:  maxLatitude,
+		MaxLatitude:  minLatitude,
+		MinLongitude: maxLongitude,
+		MaxLongitude: minLongitude,
+	}
+	for _, v := range p {
+		bbox.MinLatitude = math.Min(bbox.MinLatitude, v.Latitude)
+		bbox.MaxLatitude = math.Max(bbox.MaxLatitude, v.Latitude)
+		bbox.MinLongitude = math.Min(bbox.MinLongitude, v.Longitude)
+		bbox.MaxLongitude = math.Max(bbox.MaxLongitude, v.Longitude)
+	}
+	return bbox
+}
+
+// Contains reports whether the coordinates lie within the polygon, using the even-odd rule.
+func (p Polygon) Contains(latitude, longitude float64) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Latitude > latitude) == (b.Latitude > latitude) {
+			continue
+		}
+		lng := a.Longitude + (latitude-a.Latitude)*(b.Longitude-a.Longitude)/(b.Latitude-a.Latitude)
+		if longitude < lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// validate reports whether the polygon has at least three vertices within the valid ranges.
+func (p Polygon) validate() error {
+	if len(p) < 3 {
+		return ErrInvalidPolygon
+	}
+	for _, v := range p {
+		if err := checkCoordinates(v.Latitude, v.Longitude); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPolygon, err)
+		}
+	}
+	return nil
+}
+
+// relate classifies a cell as boundary when an edge of the polygon touches it, otherwise as inside or
+// outside depending on its center.
+func (p Polygon) relate(cell BBox) cellRelation {
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		if segmentTouchesBBox(p[j], p[i], cell) {
+			return cellBoundary
+		}
+	}
+	if p.Contains((cell.MinLatitude+cell.MaxLatitude)/2, (cell.MinLongitude+cell.MaxLongitude)/2) {
+		return cellInside
+	}
+	return cellOutside
+}
+
+// segmentTouchesBBox reports whether the segment from a to b intersects the closed bounding box,
+// clipping it with the Liang-Barsky algorithm.
+func segmentTouchesBBox(a, b LatLng, bbox BBox) bool {
+	dLat := b.Latitude - a.Latitude
+	dLng := b.Longitude - a.Longitude
+	lo, hi := 0.0, 1.0
+
+	clip := func(p, q float64) bool {
+		if p == 0 {
+			return q >= 0
+		}
+		r := q / p
+		if p < 0 {
+			lo = math.Max(lo, r)
+		} else {
+			hi = math.Min(hi, r)
+		}
+		return lo <= hi
+	}
+
+	return clip(-dLng, a.Longitude-bbox.MinLongitude) &&
+		clip(dLng, bbox.MaxLongitude-a.Longitude) &&
+		clip(-dLat, a.Latitude-bbox.MinLatitude) &&
+		clip(dLat, bbox.MaxLatitude-a.Latitude)
+}
+
+// BBox returns the bounding box of the circle, crossing the antimeridian when the circle does.
+func (c Circle) BBox() BBox {
+	bbox, _ := RadiusBBox(c.Latitude, c.Longitude, c.Radius)
+	return bbox
+}
+
+// Contains reports whether the coordinates lie within Radius meters of the center.
+func (c Circle) Contains(latitude, longitude float64) bool {
+	return Distance(c.Latitude, c.Longitude, latitude, longitude) <= c.Radius
+}
+
+// validate reports whether the circle has a valid center and radius.
+func (c Circle) validate() error {
+	_, err := RadiusBBox(c.Latitude, c.Longitude, c.Radius)
+	return err
+}
+
+// relate classifies a cell by the distances from the center to its nearest and farthest points.
+func (c Circle) relate(cell BBox) cellRelation {
+	if c.extremeDistance(cell, false) > c.Radius {
+		return cellOutside
+	}
+	if c.extremeDistance(cell, true) <= c.Radius {
+		return cellInside
+	}
+	return cellBoundary
+}
+
+// extremeDistance returns the distance in meters from the center to the farthest point of the cell, or to
+// the nearest one. Along a parallel the distance grows with the longitude difference, so the extreme points
+// lie on the meridian edges or on the meridian through the center, or its antimeridian, when the cell spans it.
+// Along a meridian the distance has a single minimum and maximum, found at the foot of the great circle
+// through the center and at its antipode, or else at an end.
+func (c Circle) extremeDistance(cell BBox, farthest bool) float64 {
+	meridians := []float64{cell.MinLongitude, cell.MaxLongitude}
+	for _, lng := range [2]float64{c.Longitude, wrapLongitude(c.Longitude + maxLongitude)} {
+		if lng > cell.MinLongitude && lng < cell.MaxLongitude {
+			meridians = append(meridians, lng)
+		}
+	}
+
+	pick := math.Min
+	distance := math.Inf(1)
+	if farthest {
+		pick = math.Max
+		distance = 0
+	}
+
+	phi := degToRad(c.Latitude)
+	for _, lng := range meridians {
+		foot := radToDeg(math.Atan2(math.Sin(phi), math.Cos(phi)*math.Cos(degToRad(lng-c.Longitude))))
+		if farthest {
+			// The antipode of the foot, as an angle along the circle of the meridian, not a longitude.
+			foot = math.Remainder(foot+180, 360)
+		}
+
+		candidates := []float64{cell.MinLatitude, cell.MaxLatitude}
+		if foot > cell.MinLatitude && foot < cell.MaxLatitude {
+			candidates = append(candidates, foot)
+		}
+		for _, lat := range candidates {
+			distance = pick(distance, Distance(c.Latitude, c.Longitude, lat, lng))
+		}
+	}
+	return distance
+}
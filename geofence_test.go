@@ -0,0 +1,278 @@
+package geohash
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// goldenGate is a rough quadrilateral around Golden Gate Park.
+	goldenGate = Polygon{
+		{37.7735, -122.5110},
+		{37.7660, -122.5105},
+		{37.7655, -122.4530},
+		{37.7730, -122.4540},
+	}
+
+	// mission is a concave polygon shaped as an L around the Mission District.
+	mission = Polygon{
+		{37.7700, -122.4270},
+		{37.7480, -122.4270},
+		{37.7480, -122.4050},
+		{37.7560, -122.4050},
+		{37.7560, -122.4180},
+		{37.7700, -122.4180},
+	}
+
+	// downtown is a circle of 1.5 km around Union Square.
+	downtown = Circle{Latitude: 37.7880, Longitude: -122.4075, Radius: 1500}
+
+	// dateLine is a circle of 50 km around Taveuni, crossing the antimeridian.
+	dateLine = Circle{Latitude: -16.8, Longitude: 179.9, Radius: 50000}
+)
+
+func newTestGeofencer(t *testing.T, dwell time.Duration) *Geofencer {
+	g, err := NewGeofencer(Building, dwell)
+	require.NoError(t, err)
+	require.NoError(t, g.AddFence("golden-gate", goldenGate))
+	require.NoError(t, g.AddFence("mission", mission))
+	require.NoError(t, g.AddFence("downtown", downtown))
+	require.NoError(t, g.AddFence("date-line", dateLine))
+	return g
+}
+
+func TestNewGeofencer(t *testing.T) {
+	_, err := NewGeofencer(0, 0)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	_, err = NewGeofencer(SubPoint+1, 0)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	g, err := NewGeofencer(Block, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, Block, g.Precision())
+}
+
+func TestGeofencerAddFence(t *testing.T) {
+	tests := []struct {
+		name    string
+		fence   Fence
+		wantErr []error
+	}{
+		{
+			name:    "Too few vertices",
+			fence:   Polygon{{0, 0}, {1, 1}},
+			wantErr: []error{ErrInvalidPolygon},
+		},
+		{
+			name:    "Vertex out of range",
+			fence:   Polygon{{0, 0}, {91, 0}, {0, 1}},
+			wantErr: []error{ErrInvalidPolygon, ErrLatitudeOutOfRange},
+		},
+		{
+			name:    "Negative radius",
+			fence:   Circle{Latitude: 0, Longitude: 0, Radius: -1},
+			wantErr: []error{ErrInvalidRadius},
+		},
+		{
+			name:    "Center out of range",
+			fence:   Circle{Latitude: 0, Longitude: 181, Radius: 1},
+			wantErr: []error{ErrLongitudeOutOfRange},
+		},
+		{
+			name:    "Duplicate identifier",
+			fence:   Circle{Latitude: 0, Longitude: 0, Radius: 1},
+			wantErr: []error{ErrDuplicateFence},
+		},
+	}
+
+	g := newTestGeofencer(t, 0)
+	require.NoError(t, g.AddFence("Duplicate identifier", Circle{Latitude: 1, Longitude: 1, Radius: 1}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := g.AddFence(tt.name, tt.fence)
+			for _, want := range tt.wantErr {
+				assert.ErrorIs(t, err, want)
+			}
+		})
+	}
+}
+
+func TestFenceCover(t *testing.T) {
+	tests := []struct {
+		name      string
+		fence     Fence
+		precision Precision
+	}{
+		{"Polygon", goldenGate, Block},
+		{"Concave polygon", mission, Block},
+		{"Circle", downtown, Building},
+		{"Circle around the pole", Circle{Latitude: 89.9, Longitude: 0, Radius: 20000}, Street},
+		{"Circle across the antimeridian", dateLine, Street},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fence := tt.fence
+			interior, boundary, err := fenceCover(fence, tt.precision)
+			require.NoError(t, err)
+			assert.NotEmpty(t, interior)
+			assert.NotEmpty(t, boundary)
+
+			coarse := false
+			for _, hash := range interior {
+				coarse = coarse || len(hash) < int(tt.precision)
+				_, _, cell, err := DecodeBBox(hash)
+				require.NoError(t, err)
+				for _, lat := range []float64{cell.MinLatitude, cell.MaxLatitude} {
+					for _, lng := range []float64{cell.MinLongitude, cell.MaxLongitude} {
+						assert.True(t, fence.Contains(lat, lng), "interior cell %s corner %v,%v", hash, lat, lng)
+					}
+				}
+			}
+			assert.True(t, coarse, "interior cells are merged above the geofencer precision")
+			for _, hash := range boundary {
+				assert.Len(t, hash, int(tt.precision))
+			}
+		})
+	}
+}
+
+func TestGeofencerFences(t *testing.T) {
+	g := newTestGeofencer(t, 0)
+
+	fences := map[string]Fence{
+		"golden-gate": goldenGate,
+		"mission":     mission,
+		"downtown":    downtown,
+		"date-line":   dateLine,
+	}
+	areas := []BBox{
+		{MinLatitude: 37.74, MaxLatitude: 37.81, MinLongitude: -122.52, MaxLongitude: -122.38},
+		{MinLatitude: -17.4, MaxLatitude: -16.2, MinLongitude: 179.2, MaxLongitude: 180},
+		{MinLatitude: -17.4, MaxLatitude: -16.2, MinLongitude: -180, MaxLongitude: -179.4},
+	}
+
+	const steps = 60
+	for _, area := range areas {
+		for i := 0; i <= steps; i++ {
+			for j := 0; j <= steps; j++ {
+				lat := area.MinLatitude + (area.MaxLatitude-area.MinLatitude)*float64(i)/steps
+				lng := area.MinLongitude + (area.MaxLongitude-area.MinLongitude)*float64(j)/steps
+
+				var want []string
+				for _, id := range []string{"date-line", "downtown", "golden-gate", "mission"} {
+					if fences[id].Contains(lat, lng) {
+						want = append(want, id)
+					}
+				}
+
+				got, err := g.Fences(lat, lng)
+				require.NoError(t, err)
+				assert.Equal(t, want, got, fmt.Sprintf("%v,%v", lat, lng))
+			}
+		}
+	}
+
+	got, err := g.Fences(37.7700, -122.4800)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"golden-gate"}, got)
+
+	_, err = g.Fences(91, 0)
+	assert.ErrorIs(t, err, ErrLatitudeOutOfRange)
+}
+
+func TestGeofencerUpdate(t *testing.T) {
+	g := newTestGeofencer(t, 10*time.Minute)
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	type step struct {
+		latitude  float64
+		longitude float64
+		after     time.Duration
+		want      []GeofenceEventType
+		wantFence []string
+	}
+	steps := []step{
+		{37.7700, -122.4800, 0, []GeofenceEventType{GeofenceEnter}, []string{"golden-gate"}},
+		{37.7705, -122.4700, 5 * time.Minute, nil, nil},
+		{37.7700, -122.4600, 10 * time.Minute, []GeofenceEventType{GeofenceDwell}, []string{"golden-gate"}},
+		{37.7700, -122.4550, 15 * time.Minute, nil, nil},
+		{37.7600, -122.4200, 20 * time.Minute, []GeofenceEventType{GeofenceExit, GeofenceEnter}, []string{"golden-gate", "mission"}},
+		{37.7520, -122.4100, 25 * time.Minute, nil, nil},
+		{37.7620, -122.4100, 30 * time.Minute, []GeofenceEventType{GeofenceExit}, []string{"mission"}},
+		{37.7880, -122.4075, 40 * time.Minute, []GeofenceEventType{GeofenceEnter}, []string{"downtown"}},
+	}
+
+	for i, s := range steps {
+		at := start.Add(s.after)
+		events, err := g.Update("truck-1", s.latitude, s.longitude, at)
+		require.NoError(t, err)
+
+		var gotTypes []GeofenceEventType
+		var gotFences []string
+		for _, e := range events {
+			gotTypes = append(gotTypes, e.Type)
+			gotFences = append(gotFences, e.Fence)
+			assert.Equal(t, "truck-1", e.Entity)
+			assert.Equal(t, at, e.Time)
+			assert.Equal(t, s.latitude, e.Latitude)
+			assert.Equal(t, s.longitude, e.Longitude)
+		}
+		assert.Equal(t, s.want, gotTypes, "step %d", i)
+		assert.Equal(t, s.wantFence, gotFences, "step %d", i)
+	}
+
+	_, err := g.Update("truck-1", 37.7880, -122.4075, start)
+	assert.ErrorIs(t, err, ErrStaleUpdate)
+
+	_, err = g.Update("truck-2", 91, 0, start)
+	assert.ErrorIs(t, err, ErrLatitudeOutOfRange)
+
+	g.Forget("truck-1")
+	events, err := g.Update("truck-1", 37.7880, -122.4075, start)
+	assert.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, GeofenceEnter, events[0].Type)
+}
+
+func TestGeofencerRemoveFence(t *testing.T) {
+	g := newTestGeofencer(t, 0)
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	events, err := g.Update("ferry", -16.8, -179.95, start)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "date-line", events[0].Fence)
+
+	assert.True(t, g.RemoveFence("date-line"))
+	assert.False(t, g.RemoveFence("date-line"))
+
+	events, err = g.Update("ferry", -16.8, 179.95, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	for hash, cells := range g.cells {
+		for _, c := range cells {
+			assert.NotEqual(t, "date-line", c.entry.id, hash)
+		}
+	}
+
+	require.NoError(t, g.AddFence("date-line", dateLine))
+	events, err = g.Update("ferry", -16.8, 179.95, start.Add(2*time.Hour))
+	assert.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, GeofenceEnter, events[0].Type)
+}
+
+func TestGeofenceEventTypeString(t *testing.T) {
+	assert.Equal(t, "enter", GeofenceEnter.String())
+	assert.Equal(t, "exit", GeofenceExit.String())
+	assert.Equal(t, "dwell", GeofenceDwell.String())
+	assert.Equal(t, "unknown", GeofenceEventType(-1).String())
+}
@@ -89,7 +89,8 @@ var (
 	// ErrPrecisionOutOfRange is returned when a precision value is outside the valid range.
 	ErrPrecisionOutOfRange = errors.New("precision out of range")
 
-	// ErrInvalidHashLength is returned when the length of a GeoHash string is outside the valid range (1 to 12).
+	// ErrInvalidHashLength is returned when the length of a GeoHash string is outside the valid range
+	// (1 to 25, or 1 to 12 for operations limited to 64-bit cells).
 	ErrInvalidHashLength = errors.New("invalid hash length")
 
 	// ErrInvalidHashFormat is returned when a GeoHash string contains characters outside the allowed Base32 alphabet.
@@ -97,46 +98,29 @@ var (
 
 	// ErrDirectionOutOfRange is returned when a direction value is outside the acceptable range of valid directions.
 	ErrDirectionOutOfRange = errors.New("direction out of range")
+
+	// ErrInvalidCoordinate is returned when a latitude or longitude is NaN or infinite.
+	ErrInvalidCoordinate = errors.New("invalid coordinate")
 )
 
-// alphabetMap maps byte characters to corresponding index.
-var alphabetMap = map[int32]uint64{
-	'0': 0,
-	'1': 1,
-	'2': 2,
-	'3': 3,
-	'4': 4,
-	'5': 5,
-	'6': 6,
-	'7': 7,
-	'8': 8,
-	'9': 9,
-	'b': 10,
-	'c': 11,
-	'd': 12,
-	'e': 13,
-	'f': 14,
-	'g': 15,
-	'h': 16,
-	'j': 17,
-	'k': 18,
-	'm': 19,
-	'n': 20,
-	'p': 21,
-	'q': 22,
-	'r': 23,
-	's': 24,
-	't': 25,
-	'u': 26,
-	'v': 27,
-	'w': 28,
-	'x': 29,
-	'y': 30,
-	'z': 31,
+// directionOffsets holds the row and column offsets of each Direction in the order: N, NE, E, SE, S, SW, W, NW.
+// Both axes wrap around, so the cells north of the top row lie on the bottom row.
+var directionOffsets = [...]struct {
+	lat int
+	lng int
+}{
+	{+1, 0},  // N
+	{+1, +1}, // NE
+	{0, +1},  // E
+	{-1, +1}, // SE
+	{-1, 0},  // S
+	{-1, -1}, // SW
+	{0, -1},  // W
+	{+1, -1}, // NW
 }
 
 type (
-	// Precision GeoHash precision Levels
+	// Precision GeoHash precision Levels, from Global to MaxPrecision characters
 	Precision int
 
 	// Direction represents cardinal or intercardinal direction
@@ -151,17 +135,18 @@ type (
 	}
 )
 
-// Encode generates a GeoHash string for the given latitude, longitude, and precision.
+// Encode generates a GeoHash string for the given latitude, longitude, and precision (1 to MaxPrecision).
 // Returns an error if the latitude, longitude, or precision is out of the valid range.
 func Encode(latitude, longitude float64, precision Precision) (string, error) {
-	if latitude < minLatitude || latitude > maxLatitude {
-		return "", ErrLatitudeOutOfRange
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
 	}
-	if longitude < minLongitude || longitude > maxLongitude {
-		return "", ErrLongitudeOutOfRange
+	if err := checkPrecision(int(precision), int(Global), int(MaxPrecision)); err != nil {
+		return "", err
 	}
-	if precision < Global || precision > SubPoint {
-		return "", ErrPrecisionOutOfRange
+	if precision > SubPoint {
+		h, _ := EncodeHash128(latitude, longitude, int(precision)*bitsPerChar)
+		return h.String(), nil
 	}
 
 	lngBitset := encodeCoordinateBitset(minLongitude, maxLongitude, longitude, true, precision)
@@ -183,8 +168,16 @@ func MustEncode(latitude, longitude float64, precision Precision) string {
 // Decode takes a GeoHash string and returns its decoded latitude and longitude.
 // Returns an error if the GeoHash string is invalid or cannot be decoded.
 func Decode(hash string) (latitude, longitude float64, err error) {
-	if len(hash) < int(Global) || len(hash) > int(SubPoint) {
-		return 0, 0, ErrInvalidHashLength
+	if err := checkHashLength(len(hash), MaxPrecision); err != nil {
+		return 0, 0, err
+	}
+	if len(hash) > int(SubPoint) {
+		h, err := ParseHash128(hash)
+		if err != nil {
+			return 0, 0, err
+		}
+		latitude, longitude, _ = h.Decode()
+		return latitude, longitude, nil
 	}
 
 	bitset, precision, err := decodeFromBase32(hash)
@@ -211,8 +204,16 @@ func MustDecode(hash string) (latitude, longitude float64) {
 // DecodeBBox decodes a GeoHash string into its center coordinates with relative bounding box (BBox).
 // Returns an error if the GeoHash string is invalid or cannot be decoded.
 func DecodeBBox(hash string) (latitude float64, longitude float64, bbox BBox, err error) {
-	if len(hash) < int(Global) || len(hash) > int(SubPoint) {
-		return 0, 0, BBox{}, ErrInvalidHashLength
+	if err := checkHashLength(len(hash), MaxPrecision); err != nil {
+		return 0, 0, BBox{}, err
+	}
+	if len(hash) > int(SubPoint) {
+		h, err := ParseHash128(hash)
+		if err != nil {
+			return 0, 0, BBox{}, err
+		}
+		latitude, longitude, bbox = h.Decode()
+		return latitude, longitude, bbox, nil
 	}
 
 	bitset, precision, err := decodeFromBase32(hash)
@@ -244,7 +245,7 @@ func MustDecodeBBox(hash string) (latitude float64, longitude float64, bbox BBox
 // Neighbor returns the neighbor of a given GeoHash in one enumerated Direction.
 // Returns an error if the input is invalid.
 func Neighbor(hash string, direction Direction) (string, error) {
-	latitude, longitude, err := Decode(hash)
+	h, err := ParseHash128(hash)
 	if err != nil {
 		return "", err
 	}
@@ -253,36 +254,8 @@ func Neighbor(hash string, direction Direction) (string, error) {
 		return "", ErrDirectionOutOfRange
 	}
 
-	totalBits := uint(len(hash)) * bitsPerChar
-	latitudeBits := totalBits / 2
-	longitudeBits := totalBits / 2
-	if totalBits%2 != 0 {
-		longitudeBits++
-	}
-
-	deltaLat := 180.0 / float64(uint(1)<<latitudeBits)
-	deltaLng := 360.0 / float64(uint(1)<<longitudeBits)
-
-	// Direction deltas in the order: N, NE, E, SE, S, SW, W, NW.
-	directionDeltas := []struct {
-		lat float64
-		lon float64
-	}{
-		{+deltaLat, 0},         // N
-		{+deltaLat, +deltaLng}, // NE
-		{0, +deltaLng},         // E
-		{-deltaLat, +deltaLng}, // SE
-		{-deltaLat, 0},         // S
-		{-deltaLat, -deltaLng}, // SW
-		{0, -deltaLng},         // W
-		{+deltaLat, -deltaLng}, // NW
-	}
-
-	d := directionDeltas[direction]
-	lat, lng := wrapCoordinates(latitude+d.lat, longitude+d.lon)
-
-	neighborHash := MustEncode(lat, lng, Precision(len(hash)))
-	return neighborHash, nil
+	d := directionOffsets[direction]
+	return h.neighbor(d.lat, d.lng).String(), nil
 }
 
 // MustNeighbor returns the neighboring GeoHash in a given direction, panicking if an error occurs during lookup.
@@ -321,58 +294,22 @@ func MustNeighbors(hash string) []string {
 
 // encodeCoordinateBitset generates a bitset for a coordinate using binary partitioning within given bounds and precision.
 func encodeCoordinateBitset(leftBound, rightBound, value float64, isLongitude bool, precision Precision) uint64 {
-	totalBits := int(precision) * bitsPerChar
-	cycles := totalBits / 2
-	if isLongitude && totalBits%2 != 0 {
-		cycles++
-	}
-
-	var bitset uint64
-	for i := 0; i < cycles; i++ {
-		avg := (leftBound + rightBound) / 2.0
-
-		bitset <<= 1
-
-		if value >= avg {
-			bitset |= 1
-			leftBound = avg
-			continue
-		}
-
-		rightBound = avg
+	latBits, lngBits := axisBits(int(precision) * bitsPerChar)
+	if isLongitude {
+		return encodeAxis(leftBound, rightBound, value, lngBits)
 	}
-
-	return bitset
+	return encodeAxis(leftBound, rightBound, value, latBits)
 }
 
 func decodeCoordinateBitset(bitset uint64, isLongitude bool, precision Precision) (max, min, center float64) {
-	leftBound := minLatitude
-	rightBound := maxLatitude
+	latBits, lngBits := axisBits(int(precision) * bitsPerChar)
 
-	totalBits := int(precision) * bitsPerChar
-	bitsToProcess := totalBits / 2
+	lower, upper := decodeAxis(minLatitude, maxLatitude, bitset, latBits)
 	if isLongitude {
-		leftBound = minLongitude
-		rightBound = maxLongitude
-
-		if totalBits%2 != 0 {
-			bitsToProcess++
-		}
-	}
-
-	var mid float64
-	for i := 0; i < bitsToProcess; i++ {
-		msb := (bitset >> (bitsToProcess - 1 - i)) & 1
-		mid = (leftBound + rightBound) / 2.0
-
-		if msb == 1 {
-			leftBound = mid
-		} else {
-			rightBound = mid
-		}
+		lower, upper = decodeAxis(minLongitude, maxLongitude, bitset, lngBits)
 	}
 
-	return leftBound, rightBound, (leftBound + rightBound) / 2.0
+	return lower, upper, (lower + upper) / 2.0
 }
 
 // interlaceBitsets interlaces latitude and longitude bitsets at the specified precision to generate a combined GeoHash bitset.
@@ -416,7 +353,7 @@ func splitBitset(bitset uint64, precision Precision) (latBitset uint64, lngBitse
 	return latBitset, lngBitset
 }
 
-// encodeToBase32 encodes a given bitset into a Base32 GeoHash string using the specified precision level.
+// encodeToBase32 encodes a given bitset into a GeoHash string of StdEncoding using the specified precision level.
 func encodeToBase32(bitset uint64, precision Precision) string {
 	const mask = 0x1F // 0b11111
 
@@ -427,20 +364,20 @@ func encodeToBase32(bitset uint64, precision Precision) string {
 	var buf [SubPoint]byte
 	for i := 0; i < p; i++ {
 		index := (bitset >> (64 - bitsPerChar)) & mask
-		buf[i] = alphabet[index]
+		buf[i] = StdEncoding.alphabet[index]
 		bitset <<= bitsPerChar
 	}
 
 	return string(buf[:p])
 }
 
-// decodeFromBase32 decodes a Base32 GeoHash string into a bitset, its precision, or an error on invalid input.
+// decodeFromBase32 decodes a GeoHash string of StdEncoding into a bitset, its precision, or an error on invalid input.
 func decodeFromBase32(hash string) (uint64, Precision, error) {
 	var bitset uint64
-	for _, char := range hash {
-		index, ok := alphabetMap[char]
+	for i, char := range hash {
+		index, ok := StdEncoding.value(char)
 		if !ok {
-			return 0, 0, ErrInvalidHashFormat
+			return 0, 0, &FormatError{Position: i, Char: char}
 		}
 
 		bitset <<= bitsPerChar
@@ -0,0 +1,164 @@
+package geohash
+
+import "unicode/utf8"
+
+const (
+	// geohash36Alphabet lists the characters of geohash-36 along its 6×6 grid, row by row from north to south
+	// and from west to east within a row. The alphabet is case-sensitive.
+	geohash36Alphabet = "23456789bBCdDFgGhHjJKlLMnNPqQrRtTVWX"
+
+	// geohash36Base is the number of rows and columns each character splits a cell into.
+	geohash36Base = 6
+
+	// geohash36MaxPrecision is the longest geohash-36, whose cells are about 15 µm wide.
+	geohash36MaxPrecision Precision = 16
+)
+
+// Geohash36 is the base-36 "geohash-36" codec. Each character splits a cell into a 6×6 grid instead of
+// interleaving bits, so its hashes cannot be expressed as an Encoding: a hash of n characters stands for a
+// cell 360°/6ⁿ wide and 180°/6ⁿ tall. Neighbors wrap around like those of Neighbor. The optional checksum
+// character of the format is not supported.
+var Geohash36 Codec = geohash36{}
+
+// geohash36DecodeMap maps the characters of geohash36Alphabet to their index, and others to -1.
+var geohash36DecodeMap = func() (m [256]int8) {
+	for i := range m {
+		m[i] = -1
+	}
+	for i := 0; i < len(geohash36Alphabet); i++ {
+		m[geohash36Alphabet[i]] = int8(i)
+	}
+	return m
+}()
+
+// geohash36 implements Codec for geohash-36.
+type geohash36 struct{}
+
+// MaxPrecision returns the longest geohash-36 supported, in characters.
+func (geohash36) MaxPrecision() Precision {
+	return geohash36MaxPrecision
+}
+
+// Encode generates the geohash-36 of the given coordinates with precision characters.
+// Returns an error if the latitude, longitude, or precision is out of the valid range.
+func (g geohash36) Encode(latitude, longitude float64, precision Precision) (string, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
+	}
+	if err := checkPrecision(int(precision), int(Global), int(geohash36MaxPrecision)); err != nil {
+		return "", err
+	}
+
+	cells := geohash36Cells(precision)
+	latIndex := geohash36Index(minLatitude, maxLatitude, latitude, cells)
+	lngIndex := geohash36Index(minLongitude, maxLongitude, longitude, cells)
+	return g.format(latIndex, lngIndex, precision), nil
+}
+
+// Decode returns the center coordinates of a geohash-36.
+// Returns an error if the hash is invalid.
+func (g geohash36) Decode(hash string) (latitude, longitude float64, err error) {
+	latitude, longitude, _, err = g.DecodeBBox(hash)
+	return latitude, longitude, err
+}
+
+// DecodeBBox returns the center coordinates and bounding box of a geohash-36.
+// Returns an error if the hash is invalid.
+func (g geohash36) DecodeBBox(hash string) (latitude, longitude float64, bbox BBox, err error) {
+	latIndex, lngIndex, err := g.parse(hash)
+	if err != nil {
+		return 0, 0, BBox{}, err
+	}
+
+	cells := float64(geohash36Cells(Precision(len(hash))))
+	bbox = BBox{
+		MinLatitude:  minLatitude + (maxLatitude-minLatitude)*float64(latIndex)/cells,
+		MaxLatitude:  minLatitude + (maxLatitude-minLatitude)*float64(latIndex+1)/cells,
+		MinLongitude: minLongitude + (maxLongitude-minLongitude)*float64(lngIndex)/cells,
+		MaxLongitude: minLongitude + (maxLongitude-minLongitude)*float64(lngIndex+1)/cells,
+	}
+	latitude = (bbox.MinLatitude + bbox.MaxLatitude) / 2
+	longitude = (bbox.MinLongitude + bbox.MaxLongitude) / 2
+	return latitude, longitude, bbox, nil
+}
+
+// Neighbor returns the neighbor of a geohash-36 in the given direction.
+// Returns an error if the hash or direction is invalid.
+func (g geohash36) Neighbor(hash string, direction Direction) (string, error) {
+	latIndex, lngIndex, err := g.parse(hash)
+	if err != nil {
+		return "", err
+	}
+	if direction < N || direction > NW {
+		return "", ErrDirectionOutOfRange
+	}
+
+	precision := Precision(len(hash))
+	cells := geohash36Cells(precision)
+	d := directionOffsets[direction]
+	return g.format(wrapIndex(latIndex, d.lat, cells), wrapIndex(lngIndex, d.lng, cells), precision), nil
+}
+
+// Neighbors returns the eight neighbors of a geohash-36, indexed by Direction.
+// Returns an error if the hash is invalid.
+func (g geohash36) Neighbors(hash string) ([]string, error) {
+	results := make([]string, len(directionOffsets))
+	for dir := N; dir <= NW; dir++ {
+		n, err := g.Neighbor(hash, dir)
+		if err != nil {
+			return nil, err
+		}
+		results[dir] = n
+	}
+	return results, nil
+}
+
+// format returns the geohash-36 of the cell at the given grid indexes, counted from the south-west corner.
+func (geohash36) format(latIndex, lngIndex uint64, precision Precision) string {
+	buf := make([]byte, precision)
+	for i := len(buf) - 1; i >= 0; i-- {
+		row := geohash36Base - 1 - latIndex%geohash36Base
+		buf[i] = geohash36Alphabet[row*geohash36Base+lngIndex%geohash36Base]
+		latIndex /= geohash36Base
+		lngIndex /= geohash36Base
+	}
+	return string(buf)
+}
+
+// parse returns the grid indexes of a geohash-36, counted from the south-west corner.
+func (geohash36) parse(hash string) (latIndex, lngIndex uint64, err error) {
+	if err := checkHashLength(len(hash), geohash36MaxPrecision); err != nil {
+		return 0, 0, err
+	}
+
+	for i := 0; i < len(hash); i++ {
+		value := geohash36DecodeMap[hash[i]]
+		if value < 0 {
+			char, _ := utf8.DecodeRuneInString(hash[i:])
+			return 0, 0, &FormatError{Position: i, Char: char}
+		}
+		latIndex = latIndex*geohash36Base + geohash36Base - 1 - uint64(value)/geohash36Base
+		lngIndex = lngIndex*geohash36Base + uint64(value)%geohash36Base
+	}
+	return latIndex, lngIndex, nil
+}
+
+// geohash36Cells returns the number of rows, or columns, of the grid of geohash-36 cells at the given precision.
+func geohash36Cells(precision Precision) uint64 {
+	cells := uint64(1)
+	for i := Global; i <= precision; i++ {
+		cells *= geohash36Base
+	}
+	return cells
+}
+
+// geohash36Index returns the index of the cell holding value when [lo, hi] is split into the given number of cells.
+func geohash36Index(lo, hi, value float64, cells uint64) uint64 {
+	index := uint64((value - lo) / (hi - lo) * float64(cells))
+	return min(index, cells-1)
+}
+
+// wrapIndex adds delta to a grid index, wrapping around the given number of cells.
+func wrapIndex(index uint64, delta int, cells uint64) uint64 {
+	return (index + cells + uint64(delta)) % cells
+}
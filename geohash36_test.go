@@ -0,0 +1,131 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeohash36Encode(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		precision Precision
+		want      string
+		wantErr   error
+	}{
+		{name: "London", latitude: 51.504444, longitude: -0.086666, precision: 10, want: "bdrdC26BqH"},
+		{name: "North-west corner", latitude: 90, longitude: -180, precision: 3, want: "222"},
+		{name: "South-east corner", latitude: -90, longitude: 180, precision: 3, want: "XXX"},
+		{name: "Latitude out of range", latitude: 91, longitude: 0, precision: 1, wantErr: ErrLatitudeOutOfRange},
+		{name: "Longitude out of range", latitude: 0, longitude: -181, precision: 1, wantErr: ErrLongitudeOutOfRange},
+		{name: "Precision too low", latitude: 0, longitude: 0, precision: 0, wantErr: ErrPrecisionOutOfRange},
+		{name: "Precision too high", latitude: 0, longitude: 0, precision: 17, wantErr: ErrPrecisionOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Geohash36.Encode(tt.latitude, tt.longitude, tt.precision)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGeohash36Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		want    BBox
+		wantErr error
+	}{
+		{
+			name: "North-west cell",
+			hash: "2",
+			want: BBox{MinLatitude: 60, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: -120},
+		},
+		{
+			name: "South-east cell",
+			hash: "X",
+			want: BBox{MinLatitude: -90, MaxLatitude: -60, MinLongitude: 120, MaxLongitude: 180},
+		},
+		{
+			name: "Second level",
+			hash: "2X",
+			want: BBox{MinLatitude: 60, MaxLatitude: 65, MinLongitude: -130, MaxLongitude: -120},
+		},
+		{name: "Empty", hash: "", wantErr: ErrInvalidHashLength},
+		{name: "Too long", hash: "22222222222222222", wantErr: ErrInvalidHashLength},
+		{name: "Character outside the alphabet", hash: "2a", wantErr: ErrInvalidHashFormat},
+		{name: "Case-sensitive", hash: "x", wantErr: ErrInvalidHashFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lng, bbox, err := Geohash36.DecodeBBox(tt.hash)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.InDelta(t, tt.want.MinLatitude, bbox.MinLatitude, 1e-9)
+			assert.InDelta(t, tt.want.MaxLatitude, bbox.MaxLatitude, 1e-9)
+			assert.InDelta(t, tt.want.MinLongitude, bbox.MinLongitude, 1e-9)
+			assert.InDelta(t, tt.want.MaxLongitude, bbox.MaxLongitude, 1e-9)
+
+			centerLat, centerLng, err := Geohash36.Decode(tt.hash)
+			require.NoError(t, err)
+			assert.Equal(t, lat, centerLat)
+			assert.Equal(t, lng, centerLng)
+		})
+	}
+}
+
+func TestGeohash36RoundTrip(t *testing.T) {
+	points := [][2]float64{{51.504444, -0.086666}, {-33.8688, 151.2093}, {0, 0}, {89.999, 179.999}}
+	for _, p := range points {
+		for precision := Global; precision <= geohash36MaxPrecision; precision++ {
+			hash, err := Geohash36.Encode(p[0], p[1], precision)
+			require.NoError(t, err)
+			require.Len(t, hash, int(precision))
+
+			_, _, bbox, err := Geohash36.DecodeBBox(hash)
+			require.NoError(t, err)
+			assert.True(t, bbox.Contains(p[0], p[1]), "%s does not contain %v", hash, p)
+		}
+	}
+}
+
+func TestGeohash36Neighbor(t *testing.T) {
+	tests := []struct {
+		name      string
+		hash      string
+		direction Direction
+		want      string
+		wantErr   error
+	}{
+		{name: "East", hash: "2", direction: E, want: "3"},
+		{name: "South", hash: "2", direction: S, want: "8"},
+		{name: "Wraps around the antimeridian", hash: "2", direction: W, want: "7"},
+		{name: "Wraps around the pole", hash: "2", direction: N, want: "R"},
+		{name: "Across a parent cell", hash: "2X", direction: SE, want: "92"},
+		{name: "Invalid direction", hash: "2", direction: NW + 1, wantErr: ErrDirectionOutOfRange},
+		{name: "Invalid hash", hash: "2a", direction: N, wantErr: ErrInvalidHashFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Geohash36.Neighbor(tt.hash, tt.direction)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	neighbors, err := Geohash36.Neighbors("2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"R", "t", "3", "9", "8", "d", "7", "X"}, neighbors)
+
+	_, err = Geohash36.Neighbors("")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+}
@@ -0,0 +1,224 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Altitude range of 3D hashes in meters, from below the lowest land to above the cruising levels of aircraft.
+const (
+	MinAltitude float64 = -1000
+	MaxAltitude float64 = 31000
+)
+
+// geohash3DAxes is the number of dimensions of a 3D hash: longitude, latitude, and altitude.
+const geohash3DAxes = 3
+
+// ErrAltitudeOutOfRange is returned when an altitude is outside [MinAltitude, MaxAltitude].
+var ErrAltitudeOutOfRange = errors.New("altitude out of range")
+
+type (
+	// Box3D is a bounding box extended with an altitude range in meters.
+	Box3D struct {
+		BBox
+		MinAltitude float64
+		MaxAltitude float64
+	}
+
+	// Direction3D is a step to one of the 26 cells surrounding a 3D cell. Each component is -1, 0, or +1:
+	// North steps towards the north pole, East towards increasing longitude, and Up towards higher altitude.
+	Direction3D struct {
+		North int
+		East  int
+		Up    int
+	}
+)
+
+// directions3D holds the 26 directions of Neighbors3D: the level below, the level of the cell, then the level
+// above, each in the order Down or Up alone followed by N, NE, E, SE, S, SW, W, NW.
+var directions3D = func() []Direction3D {
+	var result []Direction3D
+	for _, up := range []int{-1, 0, +1} {
+		if up != 0 {
+			result = append(result, Direction3D{Up: up})
+		}
+		for _, d := range directionOffsets {
+			result = append(result, Direction3D{North: d.lat, East: d.lng, Up: up})
+		}
+	}
+	return result
+}()
+
+// Encode3D generates a 3D hash for the given latitude, longitude, altitude in meters, and precision (1 to SubPoint).
+// The bits of longitude, latitude, and altitude are interleaved in turn and written with the GeoHash alphabet;
+// 2D hashes from Encode are unaffected.
+// Returns an error if the latitude, longitude, altitude, or precision is out of the valid range.
+func Encode3D(latitude, longitude, altitude float64, precision Precision) (string, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
+	}
+	if err := checkAltitude(altitude); err != nil {
+		return "", err
+	}
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return "", err
+	}
+
+	axes := axesBits(int(precision)*bitsPerChar, geohash3DAxes)
+	bitset := interleaveAxes([]uint64{
+		encodeAxis(minLongitude, maxLongitude, longitude, axes[0]),
+		encodeAxis(minLatitude, maxLatitude, latitude, axes[1]),
+		encodeAxis(MinAltitude, MaxAltitude, altitude, axes[2]),
+	}, axes)
+
+	return encodeToBase32(bitset, precision), nil
+}
+
+// MustEncode3D generates a 3D hash or panics if an error occurs.
+func MustEncode3D(latitude, longitude, altitude float64, precision Precision) string {
+	hash, err := Encode3D(latitude, longitude, altitude, precision)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// Decode3D takes a 3D hash and returns the center of its cell.
+// Returns an error if the hash is invalid.
+func Decode3D(hash string) (latitude, longitude, altitude float64, err error) {
+	latitude, longitude, altitude, _, err = DecodeBox3D(hash)
+	return latitude, longitude, altitude, err
+}
+
+// MustDecode3D decodes a 3D hash or panics if an error occurs.
+func MustDecode3D(hash string) (latitude, longitude, altitude float64) {
+	latitude, longitude, altitude, err := Decode3D(hash)
+	if err != nil {
+		panic(err)
+	}
+	return latitude, longitude, altitude
+}
+
+// DecodeBox3D decodes a 3D hash into the center of its cell along with the 3D box of the cell.
+// Returns an error if the hash is invalid.
+func DecodeBox3D(hash string) (latitude, longitude, altitude float64, box Box3D, err error) {
+	if err := validateHash(hash); err != nil {
+		return 0, 0, 0, Box3D{}, err
+	}
+
+	bitset, precision, _ := decodeFromBase32(hash)
+	axes := axesBits(int(precision)*bitsPerChar, geohash3DAxes)
+	values := deinterleaveAxes(bitset, axes)
+
+	box.MinLongitude, box.MaxLongitude = decodeAxis(minLongitude, maxLongitude, values[0], axes[0])
+	box.MinLatitude, box.MaxLatitude = decodeAxis(minLatitude, maxLatitude, values[1], axes[1])
+	box.MinAltitude, box.MaxAltitude = decodeAxis(MinAltitude, MaxAltitude, values[2], axes[2])
+
+	latitude = (box.MinLatitude + box.MaxLatitude) / 2
+	longitude = (box.MinLongitude + box.MaxLongitude) / 2
+	altitude = (box.MinAltitude + box.MaxAltitude) / 2
+	return latitude, longitude, altitude, box, nil
+}
+
+// MustDecodeBox3D decodes a 3D hash into its cell or panics if an error occurs.
+func MustDecodeBox3D(hash string) (latitude, longitude, altitude float64, box Box3D) {
+	latitude, longitude, altitude, box, err := DecodeBox3D(hash)
+	if err != nil {
+		panic(err)
+	}
+	return latitude, longitude, altitude, box
+}
+
+// Neighbor3D returns the neighbor of a 3D hash in the given direction. Like Neighbor, it wraps around
+// longitude and latitude, but there is no neighbor above the top level or below the bottom one.
+// Returns an error if the hash or direction is invalid, or the neighbor is beyond the altitude range.
+func Neighbor3D(hash string, direction Direction3D) (string, error) {
+	if err := validateHash(hash); err != nil {
+		return "", err
+	}
+	if !direction.valid() {
+		return "", ErrDirectionOutOfRange
+	}
+
+	bitset, precision, _ := decodeFromBase32(hash)
+	axes := axesBits(int(precision)*bitsPerChar, geohash3DAxes)
+	values := deinterleaveAxes(bitset, axes)
+
+	altitude := int64(values[2]) + int64(direction.Up)
+	if altitude < 0 || altitude >= int64(1)<<axes[2] {
+		return "", ErrAltitudeOutOfRange
+	}
+
+	values[0] = addWrapped(values[0], direction.East, axes[0])
+	values[1] = addWrapped(values[1], direction.North, axes[1])
+	values[2] = uint64(altitude)
+	return encodeToBase32(interleaveAxes(values, axes), precision), nil
+}
+
+// MustNeighbor3D returns the neighbor of a 3D hash in the given direction or panics if an error occurs.
+func MustNeighbor3D(hash string, direction Direction3D) string {
+	n, err := Neighbor3D(hash, direction)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// Neighbors3D returns the 26 neighbors of a 3D hash in the order of Directions3D.
+// Neighbors beyond the altitude range are left empty.
+// Returns an error if the hash is invalid.
+func Neighbors3D(hash string) ([]string, error) {
+	results := make([]string, len(directions3D))
+	for i, direction := range directions3D {
+		n, err := Neighbor3D(hash, direction)
+		if errors.Is(err, ErrAltitudeOutOfRange) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		results[i] = n
+	}
+	return results, nil
+}
+
+// MustNeighbors3D returns the 26 neighbors of a 3D hash or panics if an error occurs.
+func MustNeighbors3D(hash string) []string {
+	neighbors, err := Neighbors3D(hash)
+	if err != nil {
+		panic(err)
+	}
+	return neighbors
+}
+
+// Directions3D returns the 26 directions around a 3D cell: the level below, the level of the cell, then the
+// level above, each starting with the purely vertical step, if any, followed by N, NE, E, SE, S, SW, W, NW.
+func Directions3D() []Direction3D {
+	return append([]Direction3D(nil), directions3D...)
+}
+
+// Contains reports whether the coordinates and altitude lie within the box, edges included.
+func (b Box3D) Contains(latitude, longitude, altitude float64) bool {
+	return altitude >= b.MinAltitude && altitude <= b.MaxAltitude && b.BBox.Contains(latitude, longitude)
+}
+
+// valid reports whether every component of the direction is -1, 0, or +1 and at least one is not zero.
+func (d Direction3D) valid() bool {
+	for _, c := range [...]int{d.North, d.East, d.Up} {
+		if c < -1 || c > 1 {
+			return false
+		}
+	}
+	return d != Direction3D{}
+}
+
+// checkAltitude returns a *RangeError if the altitude is outside [MinAltitude, MaxAltitude].
+func checkAltitude(altitude float64) error {
+	if !isFinite(altitude) {
+		return fmt.Errorf("%w: altitude is %v", ErrInvalidCoordinate, altitude)
+	}
+	if altitude < MinAltitude || altitude > MaxAltitude {
+		return &RangeError{Err: ErrAltitudeOutOfRange, Value: altitude, Min: MinAltitude, Max: MaxAltitude}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode3D(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		altitude  float64
+		precision Precision
+		want      string
+		wantErr   error
+	}{
+		{name: "Global", latitude: 37.6213, longitude: -122.3790, altitude: 3048, precision: Global, want: "8"},
+		{name: "Region", latitude: 37.6213, longitude: -122.3790, altitude: 3048, precision: Region, want: "8fn8"},
+		{name: "Block", latitude: 37.6213, longitude: -122.3790, altitude: 3048, precision: Block, want: "8fn85pq8"},
+		{name: "SubPoint", latitude: 37.6213, longitude: -122.3790, altitude: 3048, precision: SubPoint, want: "8fn85pq81u6q"},
+		{name: "Lowest corner", latitude: -90, longitude: -180, altitude: MinAltitude, precision: City, want: "00000"},
+		{name: "Highest corner", latitude: 90, longitude: 180, altitude: MaxAltitude, precision: City, want: "zzzzz"},
+		{name: "Altitude below range", latitude: 0, longitude: 0, altitude: -1001, precision: City, wantErr: ErrAltitudeOutOfRange},
+		{name: "Altitude above range", latitude: 0, longitude: 0, altitude: 31001, precision: City, wantErr: ErrAltitudeOutOfRange},
+		{name: "Altitude NaN", latitude: 0, longitude: 0, altitude: math.NaN(), precision: City, wantErr: ErrInvalidCoordinate},
+		{name: "Latitude out of range", latitude: -91, longitude: 0, altitude: 0, precision: City, wantErr: ErrLatitudeOutOfRange},
+		{name: "Precision out of range", latitude: 0, longitude: 0, altitude: 0, precision: SubPoint + 1, wantErr: ErrPrecisionOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Encode3D(tt.latitude, tt.longitude, tt.altitude, tt.precision)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Panics(t, func() { MustEncode3D(tt.latitude, tt.longitude, tt.altitude, tt.precision) })
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	var rangeErr *RangeError
+	_, err := Encode3D(0, 0, 40000, City)
+	require.ErrorAs(t, err, &rangeErr)
+	assert.Equal(t, "altitude out of range: 40000 not in [-1000, 31000]", err.Error())
+}
+
+func TestDecodeBox3D(t *testing.T) {
+	lat, lng, alt, box, err := DecodeBox3D("8fn8")
+	require.NoError(t, err)
+	assert.Equal(t, Box3D{
+		BBox:        BBox{MinLatitude: 36.5625, MaxLatitude: 37.96875, MinLongitude: -123.75, MaxLongitude: -120.9375},
+		MinAltitude: 3000,
+		MaxAltitude: 3500,
+	}, box)
+	assert.Equal(t, 37.265625, lat)
+	assert.Equal(t, -122.34375, lng)
+	assert.Equal(t, 3250.0, alt)
+
+	lat2, lng2, alt2 := MustDecode3D("8fn8")
+	assert.Equal(t, [3]float64{lat, lng, alt}, [3]float64{lat2, lng2, alt2})
+
+	_, _, _, err = Decode3D("8fna")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+	_, _, _, _, err = DecodeBox3D("8fn85pq81u6q8")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+	assert.Panics(t, func() { MustDecode3D("") })
+	assert.Panics(t, func() { MustDecodeBox3D("") })
+}
+
+func TestEncode3DRoundTrip(t *testing.T) {
+	points := [][3]float64{
+		{37.6213, -122.3790, 3048},
+		{-33.9399, 151.1753, 11000},
+		{27.9881, 86.9250, 8848},
+		{31.5590, 35.4732, -430},
+	}
+
+	for _, p := range points {
+		full := MustEncode3D(p[0], p[1], p[2], SubPoint)
+		for precision := Global; precision <= SubPoint; precision++ {
+			hash := MustEncode3D(p[0], p[1], p[2], precision)
+			assert.Equal(t, full[:precision], hash)
+
+			lat, lng, alt, box := MustDecodeBox3D(hash)
+			assert.True(t, box.Contains(p[0], p[1], p[2]))
+			assert.True(t, box.Contains(lat, lng, alt))
+		}
+	}
+}
+
+func TestNeighbor3D(t *testing.T) {
+	const hash = "9q8yyk"
+	_, _, _, box := MustDecodeBox3D(hash)
+	height := box.MaxLatitude - box.MinLatitude
+	width := box.MaxLongitude - box.MinLongitude
+	depth := box.MaxAltitude - box.MinAltitude
+
+	neighbors, err := Neighbors3D(hash)
+	require.NoError(t, err)
+	require.Len(t, neighbors, 26)
+
+	seen := map[string]bool{hash: true}
+	for i, d := range Directions3D() {
+		_, _, _, nbox := MustDecodeBox3D(neighbors[i])
+		assert.InDelta(t, box.MinLatitude+float64(d.North)*height, nbox.MinLatitude, tolerance, "%+v", d)
+		assert.InDelta(t, box.MinLongitude+float64(d.East)*width, nbox.MinLongitude, tolerance, "%+v", d)
+		assert.InDelta(t, box.MinAltitude+float64(d.Up)*depth, nbox.MinAltitude, tolerance, "%+v", d)
+		assert.Equal(t, neighbors[i], MustNeighbor3D(hash, d))
+
+		assert.False(t, seen[neighbors[i]], "duplicate neighbor %s", neighbors[i])
+		seen[neighbors[i]] = true
+	}
+
+	assert.Equal(t, Direction3D{Up: -1}, Directions3D()[0])
+	assert.Equal(t, Direction3D{North: 1, Up: -1}, Directions3D()[1])
+	assert.Equal(t, Direction3D{North: 1}, Directions3D()[9])
+	assert.Equal(t, Direction3D{Up: 1}, Directions3D()[17])
+}
+
+func TestNeighbor3DEdges(t *testing.T) {
+	// Global cells only split altitude in two levels, so every cell is on the top or bottom level.
+	bottom, err := Neighbors3D("0")
+	require.NoError(t, err)
+	top, err := Neighbors3D("z")
+	require.NoError(t, err)
+
+	for i, d := range Directions3D() {
+		assert.Equal(t, d.Up < 0, bottom[i] == "", "%+v", d)
+		assert.Equal(t, d.Up > 0, top[i] == "", "%+v", d)
+	}
+
+	// Longitude and latitude wrap around as with Neighbor.
+	assert.Equal(t, "4", MustNeighbor3D("0", Direction3D{Up: 1}))
+	assert.Equal(t, "9", MustNeighbor3D("0", Direction3D{North: -1}))
+	assert.Equal(t, "k", MustNeighbor3D("0", Direction3D{East: -1}))
+
+	_, err = Neighbor3D("0", Direction3D{Up: -1})
+	assert.ErrorIs(t, err, ErrAltitudeOutOfRange)
+	_, err = Neighbor3D("0", Direction3D{})
+	assert.ErrorIs(t, err, ErrDirectionOutOfRange)
+	_, err = Neighbor3D("0", Direction3D{North: 2})
+	assert.ErrorIs(t, err, ErrDirectionOutOfRange)
+	_, err = Neighbor3D("a", Direction3D{North: 1})
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+	_, err = Neighbors3D("a")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+
+	assert.Panics(t, func() { MustNeighbor3D("0", Direction3D{Up: -1}) })
+	assert.Panics(t, func() { MustNeighbors3D("a") })
+}
+
+func TestBox3DContains(t *testing.T) {
+	box := Box3D{
+		BBox:        BBox{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 0, MaxLongitude: 10},
+		MinAltitude: 100,
+		MaxAltitude: 200,
+	}
+	assert.True(t, box.Contains(5, 5, 150))
+	assert.True(t, box.Contains(10, 10, 200))
+	assert.False(t, box.Contains(5, 5, 99))
+	assert.False(t, box.Contains(11, 5, 150))
+}
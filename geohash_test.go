@@ -63,7 +63,7 @@ func TestEncode(t *testing.T) {
 			name:      "Invalid precision - too high",
 			latitude:  0.0,
 			longitude: 0.0,
-			precision: 13,
+			precision: 26,
 			want:      "",
 			wantErr:   ErrPrecisionOutOfRange,
 		},
@@ -260,7 +260,7 @@ func TestMustEncode(t *testing.T) {
 			args: args{
 				latitude:  0.0,
 				longitude: 0.0,
-				precision: 13,
+				precision: 26,
 			},
 			wantPanic: true,
 		},
@@ -309,7 +309,7 @@ func TestDecode(t *testing.T) {
 		{
 			name: "Invalid hash - too long",
 			args: args{
-				hash: "9q8yyk8ytpxrs",
+				hash: "9q8yyk8ytpxrs9q8yyk8ytpxrs",
 			},
 			wantLatitude:  0,
 			wantLongitude: 0,
@@ -490,7 +490,7 @@ func TestMustDecode(t *testing.T) {
 		},
 		{
 			name:      "Invalid GeoHash - Too Long",
-			hash:      "9q8yyk8ytpxrs",
+			hash:      "9q8yyk8ytpxrs9q8yyk8ytpxrs",
 			wantLat:   0,
 			wantLon:   0,
 			wantPanic: true,
@@ -542,7 +542,7 @@ func TestDecodeBBox(t *testing.T) {
 		},
 		{
 			name:    "Invalid GeoHash - Too Long",
-			hash:    "9q8yyk8ytpxrs",
+			hash:    "9q8yyk8ytpxrs9q8yyk8ytpxrs",
 			wantLat: 0, wantLon: 0,
 			wantBBox: BBox{},
 			wantErr:  assert.Error,
@@ -637,7 +637,7 @@ func TestMustDecodeBBox(t *testing.T) {
 		},
 		{
 			name:      "Invalid GeoHash - Too Long",
-			hash:      "9q8yyk8ytpxrs",
+			hash:      "9q8yyk8ytpxrs9q8yyk8ytpxrs",
 			wantPanic: true,
 		},
 		{
@@ -749,7 +749,7 @@ func TestNeighbourhoods(t *testing.T) {
 		},
 		{
 			name:      "Invalid GeoHash - Too Long",
-			hash:      "9q8yyk8ytpxrs",
+			hash:      "9q8yyk8ytpxrs9q8yyk8ytpxrs",
 			want:      nil,
 			wantError: assert.Error,
 		},
@@ -822,7 +822,7 @@ func TestMustNeighbors(t *testing.T) {
 		},
 		{
 			name:        "Invalid GeoHash - Too Long",
-			args:        args{hash: "9q8yyk8ytpxrs"},
+			args:        args{hash: "9q8yyk8ytpxrs9q8yyk8ytpxrs"},
 			want:        nil,
 			expectPanic: true,
 		},
@@ -923,7 +923,7 @@ func TestNeighbor(t *testing.T) {
 		},
 		{
 			name:    "Invalid GeoHash - Too Long",
-			args:    args{hash: "9q8yyk8ytpxrs", direction: N},
+			args:    args{hash: "9q8yyk8ytpxrs9q8yyk8ytpxrs", direction: N},
 			want:    "",
 			wantErr: assert.Error,
 		},
@@ -1018,7 +1018,7 @@ func TestMustNeighbor(t *testing.T) {
 		},
 		{
 			name:        "Invalid GeoHash - Too Long",
-			args:        args{hash: "9q8yyk8ytpxrs", direction: N},
+			args:        args{hash: "9q8yyk8ytpxrs9q8yyk8ytpxrs", direction: N},
 			want:        "",
 			expectPanic: true,
 		},
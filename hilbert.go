@@ -0,0 +1,172 @@
+package geohash
+
+// Hilbert hashes identify the same cells as GeoHashes of equal length, but number them along a Hilbert curve
+// instead of a Z-order curve, so that consecutive hashes are always adjacent cells. They use the GeoHash
+// base32 alphabet. Unlike GeoHashes, a Hilbert hash is not a prefix of the hashes of its sub-cells.
+//
+// A cell with an even number of bits sits on a square grid walked by a single Hilbert curve. With an odd number
+// of bits the grid has twice as many columns as rows: the first bit selects the western or eastern half and a
+// Hilbert curve walks each half, the western curve ending next to where the eastern one starts.
+
+// EncodeHilbert returns the Hilbert hash of the given coordinates at the given precision (1 to 12).
+// Returns an error if the latitude, longitude, or precision is out of the valid range.
+func EncodeHilbert(latitude, longitude float64, precision Precision) (string, error) {
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return "", err
+	}
+	hash, err := Encode(latitude, longitude, precision)
+	if err != nil {
+		return "", err
+	}
+	return HashToHilbert(hash)
+}
+
+// MustEncodeHilbert returns the Hilbert hash of the given coordinates or panics if an error occurs.
+func MustEncodeHilbert(latitude, longitude float64, precision Precision) string {
+	hash, err := EncodeHilbert(latitude, longitude, precision)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// DecodeHilbert returns the center coordinates of a Hilbert hash cell.
+// Returns an error if the hash is invalid.
+func DecodeHilbert(hash string) (latitude, longitude float64, err error) {
+	geohash, err := HilbertToHash(hash)
+	if err != nil {
+		return 0, 0, err
+	}
+	return Decode(geohash)
+}
+
+// MustDecodeHilbert returns the center coordinates of a Hilbert hash cell or panics if an error occurs.
+func MustDecodeHilbert(hash string) (latitude, longitude float64) {
+	lat, lng, err := DecodeHilbert(hash)
+	if err != nil {
+		panic(err)
+	}
+	return lat, lng
+}
+
+// DecodeHilbertBBox returns the center coordinates and bounding box of a Hilbert hash cell.
+// Returns an error if the hash is invalid.
+func DecodeHilbertBBox(hash string) (latitude, longitude float64, bbox BBox, err error) {
+	geohash, err := HilbertToHash(hash)
+	if err != nil {
+		return 0, 0, BBox{}, err
+	}
+	return DecodeBBox(geohash)
+}
+
+// HilbertNeighbor returns the Hilbert hash of the neighboring cell in the given direction.
+// Returns an error if the hash or direction is invalid.
+func HilbertNeighbor(hash string, direction Direction) (string, error) {
+	geohash, err := HilbertToHash(hash)
+	if err != nil {
+		return "", err
+	}
+	neighbor, err := Neighbor(geohash, direction)
+	if err != nil {
+		return "", err
+	}
+	return HashToHilbert(neighbor)
+}
+
+// HilbertNeighbors returns the Hilbert hashes of the eight neighboring cells, indexed by Direction.
+// Returns an error if the hash is invalid.
+func HilbertNeighbors(hash string) ([]string, error) {
+	geohash, err := HilbertToHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	neighbors, err := Neighbors(geohash)
+	if err != nil {
+		return nil, err
+	}
+	for i, neighbor := range neighbors {
+		neighbors[i], _ = HashToHilbert(neighbor)
+	}
+	return neighbors, nil
+}
+
+// HashToHilbert returns the Hilbert hash of the cell identified by a GeoHash.
+// Returns an error if the hash is invalid.
+func HashToHilbert(hash string) (string, error) {
+	if err := validateHash(hash); err != nil {
+		return "", err
+	}
+
+	bitset, precision, _ := decodeFromBase32(hash)
+	latBitset, lngBitset := splitBitset(bitset, precision)
+	return encodeToBase32(hilbertIndex(latBitset, lngBitset, precision), precision), nil
+}
+
+// HilbertToHash returns the GeoHash of the cell identified by a Hilbert hash.
+// Returns an error if the hash is invalid.
+func HilbertToHash(hash string) (string, error) {
+	if err := validateHash(hash); err != nil {
+		return "", err
+	}
+
+	index, precision, _ := decodeFromBase32(hash)
+	latBitset, lngBitset := hilbertCell(index, precision)
+	return encodeToBase32(interlaceBitsets(latBitset, lngBitset, precision), precision), nil
+}
+
+// hilbertIndex returns the position along the Hilbert curve of the cell at the given row and column.
+func hilbertIndex(row, column uint64, precision Precision) uint64 {
+	totalBits := uint(precision) * bitsPerChar
+	order := totalBits / 2
+	side := uint64(1) << order
+
+	var half uint64
+	if totalBits%2 == 1 {
+		half = column >> order
+		column &= side - 1
+	}
+
+	var index uint64
+	for s := side / 2; s > 0; s /= 2 {
+		var rx, ry uint64
+		if column&s != 0 {
+			rx = 1
+		}
+		if row&s != 0 {
+			ry = 1
+		}
+		index += s * s * ((3 * rx) ^ ry)
+		column, row = hilbertRotate(side, column, row, rx, ry)
+	}
+	return half<<(2*order) | index
+}
+
+// hilbertCell returns the row and column of the cell at the given position along the Hilbert curve.
+func hilbertCell(index uint64, precision Precision) (row, column uint64) {
+	totalBits := uint(precision) * bitsPerChar
+	order := totalBits / 2
+	side := uint64(1) << order
+
+	half := index >> (2 * order)
+	t := index & (side*side - 1)
+	for s := uint64(1); s < side; s *= 2 {
+		rx := 1 & (t / 2)
+		ry := 1 & (t ^ rx)
+		column, row = hilbertRotate(s, column, row, rx, ry)
+		column += s * rx
+		row += s * ry
+		t /= 4
+	}
+	return row, half<<order | column
+}
+
+// hilbertRotate rotates and flips a quadrant of side n so that the curve keeps its orientation.
+func hilbertRotate(n, x, y, rx, ry uint64) (uint64, uint64) {
+	if ry == 0 {
+		if rx == 1 {
+			x, y = n-1-x, n-1-y
+		}
+		x, y = y, x
+	}
+	return x, y
+}
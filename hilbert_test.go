@@ -0,0 +1,112 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeHilbert(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		precision Precision
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "Latitude out of range",
+			latitude:  91,
+			precision: City,
+			wantErr:   ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Precision out of range",
+			precision: 13,
+			wantErr:   ErrPrecisionOutOfRange,
+		},
+		{
+			name:      "Odd bit count",
+			latitude:  37.7749,
+			longitude: -122.4194,
+			precision: City,
+			want:      "79dpm",
+		},
+		{
+			name:      "Even bit count",
+			latitude:  37.7749,
+			longitude: -122.4194,
+			precision: Street,
+			want:      "9esv0v",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeHilbert(tt.latitude, tt.longitude, tt.precision)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeHilbert(t *testing.T) {
+	lat, lng, bbox, err := DecodeHilbertBBox("79dpm")
+	require.NoError(t, err)
+	wantLat, wantLng, wantBBox := MustDecodeBBox("9q8yy")
+	assert.Equal(t, wantBBox, bbox)
+	assert.InDelta(t, wantLat, lat, tolerance)
+	assert.InDelta(t, wantLng, lng, tolerance)
+
+	lat, lng = MustDecodeHilbert("79dpm")
+	assert.InDelta(t, wantLat, lat, tolerance)
+	assert.InDelta(t, wantLng, lng, tolerance)
+
+	_, _, err = DecodeHilbert("")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+	_, _, _, err = DecodeHilbertBBox("79dpa")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+}
+
+func TestHilbertNeighbor(t *testing.T) {
+	got, err := HilbertNeighbors("79dpm")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"79dpf", "79dpg", "79dph", "79dpj", "79dpk", "79dpr", "79dpn", "79dp1"}, got)
+
+	n, err := HilbertNeighbor("79dpm", E)
+	require.NoError(t, err)
+	assert.Equal(t, "79dph", n)
+
+	_, err = HilbertNeighbor("79dpm", 8)
+	assert.ErrorIs(t, err, ErrDirectionOutOfRange)
+}
+
+func TestHilbertConversion(t *testing.T) {
+	_, err := HashToHilbert("9q8yya")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+	_, err = HilbertToHash("0123456789bcd")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+
+	// Every index converts back to itself and is edge-adjacent to the previous one, across both halves
+	// of odd-bit grids.
+	for precision := Global; precision <= Country; precision++ {
+		var previous string
+		for i := uint64(0); i < 1<<(uint(precision)*bitsPerChar); i++ {
+			hilbert := encodeToBase32(i, precision)
+			hash, err := HilbertToHash(hilbert)
+			require.NoError(t, err)
+			back, err := HashToHilbert(hash)
+			require.NoError(t, err)
+			require.Equal(t, hilbert, back)
+
+			if previous != "" {
+				neighbors := MustNeighbors(previous)
+				require.Contains(t, []string{neighbors[N], neighbors[E], neighbors[S], neighbors[W]}, hash,
+					"%s follows %s", hash, previous)
+			}
+			previous = hash
+		}
+	}
+}
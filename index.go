@@ -0,0 +1,201 @@
+package geohash
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+type (
+	// Item is a value located at a latitude and longitude.
+	Item[T any] struct {
+		Latitude  float64
+		Longitude float64
+		Value     T
+	}
+
+	// Index is an in-memory spatial index that buckets values by their GeoHash cell at a fixed precision.
+	// It is safe for concurrent readers alongside a single writer.
+	Index[T comparable] struct {
+		mu        sync.RWMutex
+		precision Precision
+		cells     map[string][]Item[T]
+		size      int
+	}
+)
+
+// NewIndex creates an empty Index bucketing values at the given precision.
+// Returns an error if the precision is out of the valid range.
+func NewIndex[T comparable](precision Precision) (*Index[T], error) {
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return nil, err
+	}
+
+	return &Index[T]{
+		precision: precision,
+		cells:     make(map[string][]Item[T]),
+	}, nil
+}
+
+// Precision returns the precision at which the index buckets values.
+func (x *Index[T]) Precision() Precision {
+	return x.precision
+}
+
+// Len returns the number of values stored in the index.
+func (x *Index[T]) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.size
+}
+
+// Insert adds a value at the given coordinates.
+// Returns an error if the latitude or longitude is out of range.
+func (x *Index[T]) Insert(latitude, longitude float64, value T) error {
+	hash, err := Encode(latitude, longitude, x.precision)
+	if err != nil {
+		return err
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.cells[hash] = append(x.cells[hash], Item[T]{Latitude: latitude, Longitude: longitude, Value: value})
+	x.size++
+	return nil
+}
+
+// Remove deletes one occurrence of the value stored at the given coordinates and reports whether it was found.
+func (x *Index[T]) Remove(latitude, longitude float64, value T) bool {
+	hash, err := Encode(latitude, longitude, x.precision)
+	if err != nil {
+		return false
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	items := x.cells[hash]
+	for i, item := range items {
+		if item.Value != value || item.Latitude != latitude || item.Longitude != longitude {
+			continue
+		}
+
+		items = append(items[:i], items[i+1:]...)
+		if len(items) == 0 {
+			delete(x.cells, hash)
+		} else {
+			x.cells[hash] = items
+		}
+		x.size--
+		return true
+	}
+	return false
+}
+
+// QueryBBox returns the items lying within the bounding box.
+// Returns an error if the bounding box is invalid.
+func (x *Index[T]) QueryBBox(bbox BBox) ([]Item[T], error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.queryBBox(bbox, func(Item[T]) bool { return true })
+}
+
+// QueryRadius returns the items within radius meters of the given coordinates.
+// Returns an error if the coordinates are out of range or the radius is invalid.
+func (x *Index[T]) QueryRadius(latitude, longitude, radius float64) ([]Item[T], error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.queryRadius(latitude, longitude, radius)
+}
+
+// KNearest returns up to k items closest to the given coordinates, ordered by increasing distance.
+// Returns an error if the coordinates are out of range.
+func (x *Index[T]) KNearest(latitude, longitude float64, k int) ([]Item[T], error) {
+	if _, err := RadiusBBox(latitude, longitude, 0); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// Grow the search radius from one cell height until it holds k items; any item closer than the
+	// k-th one found is then guaranteed to be inside the searched circle.
+	latBits := int(x.precision) * bitsPerChar / 2
+	radius := degToRad(180/float64(uint64(1)<<latBits)) * earthRadius
+	for {
+		items, err := x.queryRadius(latitude, longitude, radius)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(items) >= k || radius >= math.Pi*earthRadius || len(items) == x.size {
+			sortByDistance(items, latitude, longitude)
+			if len(items) > k {
+				items = items[:k]
+			}
+			return items, nil
+		}
+		radius *= 2
+	}
+}
+
+// queryRadius collects the items within the circle. The caller must hold the read lock.
+func (x *Index[T]) queryRadius(latitude, longitude, radius float64) ([]Item[T], error) {
+	bbox, err := RadiusBBox(latitude, longitude, radius)
+	if err != nil {
+		return nil, err
+	}
+
+	return x.queryBBox(bbox, func(item Item[T]) bool {
+		return Distance(latitude, longitude, item.Latitude, item.Longitude) <= radius
+	})
+}
+
+// queryBBox collects the items within the bounding box accepted by keep. The caller must hold the read lock.
+func (x *Index[T]) queryBBox(bbox BBox, keep func(Item[T]) bool) ([]Item[T], error) {
+	var result []Item[T]
+	collect := func(items []Item[T]) {
+		for _, item := range items {
+			if bbox.Contains(item.Latitude, item.Longitude) && keep(item) {
+				result = append(result, item)
+			}
+		}
+	}
+
+	grid, err := newCoverGrid(bbox, x.precision)
+	if err != nil {
+		return nil, err
+	}
+	if grid.size() > uint64(len(x.cells)) {
+		// Scanning every occupied cell is cheaper than walking the covering.
+		for _, items := range x.cells {
+			collect(items)
+		}
+		return result, nil
+	}
+
+	cover, err := Cover(bbox, x.precision)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hash := range cover {
+		collect(x.cells[hash])
+	}
+	return result, nil
+}
+
+// sortByDistance orders items by increasing distance from the given coordinates.
+func sortByDistance[T any](items []Item[T], latitude, longitude float64) {
+	sort.SliceStable(items, func(a, b int) bool {
+		return Distance(latitude, longitude, items[a].Latitude, items[a].Longitude) <
+			Distance(latitude, longitude, items[b].Latitude, items[b].Longitude)
+	})
+}
@@ -0,0 +1,161 @@
+package geohash
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type place struct {
+	name      string
+	latitude  float64
+	longitude float64
+}
+
+var places = []place{
+	{"San Francisco", 37.7749, -122.4194},
+	{"Oakland", 37.8044, -122.2712},
+	{"San Jose", 37.3382, -121.8863},
+	{"Los Angeles", 34.0522, -118.2437},
+	{"New York", 40.7128, -74.0060},
+	{"Suva", -18.1248, 178.4501},
+	{"Apia", -13.8507, -171.7514},
+}
+
+func newPlacesIndex(t *testing.T) *Index[string] {
+	index, err := NewIndex[string](Street)
+	require.NoError(t, err)
+	for _, p := range places {
+		require.NoError(t, index.Insert(p.latitude, p.longitude, p.name))
+	}
+	return index
+}
+
+func itemValues[T any](items []Item[T]) []T {
+	values := make([]T, len(items))
+	for i, item := range items {
+		values[i] = item.Value
+	}
+	return values
+}
+
+func TestNewIndex(t *testing.T) {
+	_, err := NewIndex[string](0)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	index, err := NewIndex[string](City)
+	assert.NoError(t, err)
+	assert.Equal(t, City, index.Precision())
+	assert.Equal(t, 0, index.Len())
+}
+
+func TestIndexInsertRemove(t *testing.T) {
+	index := newPlacesIndex(t)
+	assert.Equal(t, len(places), index.Len())
+
+	assert.ErrorIs(t, index.Insert(91, 0, "nowhere"), ErrLatitudeOutOfRange)
+	assert.False(t, index.Remove(37.7749, -122.4194, "Oakland"))
+	assert.False(t, index.Remove(91, 0, "nowhere"))
+	assert.True(t, index.Remove(37.7749, -122.4194, "San Francisco"))
+	assert.False(t, index.Remove(37.7749, -122.4194, "San Francisco"))
+	assert.Equal(t, len(places)-1, index.Len())
+}
+
+func TestIndexQueryBBox(t *testing.T) {
+	index := newPlacesIndex(t)
+
+	tests := []struct {
+		name    string
+		bbox    BBox
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Invalid bbox",
+			bbox:    BBox{MinLatitude: 10, MaxLatitude: 0},
+			wantErr: ErrInvalidBBox,
+		},
+		{
+			name: "Bay Area",
+			bbox: BBox{MinLatitude: 37, MaxLatitude: 38, MinLongitude: -123, MaxLongitude: -121},
+			want: []string{"San Francisco", "Oakland", "San Jose"},
+		},
+		{
+			name: "Crossing the antimeridian",
+			bbox: BBox{MinLatitude: -20, MaxLatitude: -10, MinLongitude: 170, MaxLongitude: -170},
+			want: []string{"Suva", "Apia"},
+		},
+		{
+			name: "Whole world",
+			bbox: BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+			want: []string{"San Francisco", "Oakland", "San Jose", "Los Angeles", "New York", "Suva", "Apia"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := index.QueryBBox(tt.bbox)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.ElementsMatch(t, tt.want, itemValues(got))
+		})
+	}
+}
+
+func TestIndexQueryRadius(t *testing.T) {
+	index := newPlacesIndex(t)
+
+	_, err := index.QueryRadius(37.7749, -122.4194, -1)
+	assert.ErrorIs(t, err, ErrInvalidRadius)
+
+	got, err := index.QueryRadius(37.7749, -122.4194, 20000)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"San Francisco", "Oakland"}, itemValues(got))
+
+	got, err = index.QueryRadius(37.7749, -122.4194, 100000)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"San Francisco", "Oakland", "San Jose"}, itemValues(got))
+}
+
+func TestIndexKNearest(t *testing.T) {
+	index := newPlacesIndex(t)
+
+	_, err := index.KNearest(91, 0, 1)
+	assert.ErrorIs(t, err, ErrLatitudeOutOfRange)
+
+	got, err := index.KNearest(37.7749, -122.4194, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = index.KNearest(37.7, -122.3, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Oakland", "San Francisco", "San Jose"}, itemValues(got))
+
+	got, err = index.KNearest(-15, -179, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Suva", "Apia"}, itemValues(got))
+
+	got, err = index.KNearest(0, 0, 100)
+	assert.NoError(t, err)
+	assert.Len(t, got, len(places))
+}
+
+func TestIndexConcurrentReaders(t *testing.T) {
+	index := newPlacesIndex(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = index.KNearest(37.7749, -122.4194, 2)
+			_, _ = index.QueryRadius(37.7749, -122.4194, 50000)
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		_ = index.Insert(37.7749, -122.4194, "San Francisco")
+	}
+	wg.Wait()
+	assert.Equal(t, len(places)+100, index.Len())
+}
@@ -0,0 +1,166 @@
+package geohash
+
+import (
+	"math"
+	"sort"
+)
+
+// Ring returns the cells at exactly k steps (Chebyshev distance) from the given GeoHash, in no particular order.
+// Ring(hash, 0) is the cell itself and Ring(hash, 1) holds the same cells as Neighbors. Rings wrap around the
+// antimeridian but stop at the poles, so rings near a pole hold fewer cells.
+// Returns an error if the hash is invalid or k is negative.
+func Ring(hash string, k int) ([]string, error) {
+	if err := validateHash(hash); err != nil {
+		return nil, err
+	}
+	if k < 0 {
+		return nil, ErrInvalidRadius
+	}
+
+	bitset, precision, _ := decodeFromBase32(hash)
+	latIndex, lngIndex := splitBitset(bitset, precision)
+	return ringCells(int64(latIndex), int64(lngIndex), k, precision, make(map[int64]bool)), nil
+}
+
+// MustRing returns the cells at k steps from the given GeoHash or panics if an error occurs.
+func MustRing(hash string, k int) []string {
+	ring, err := Ring(hash, k)
+	if err != nil {
+		panic(err)
+	}
+	return ring
+}
+
+// KNN returns up to k items closest to the given coordinates within maxRadius meters, ordered by increasing
+// distance, from any backing store reachable through fetch, which returns the items stored in a cell. Cells at
+// the given precision are visited in rings around Encode(latitude, longitude, precision) until no unvisited
+// cell can hold an item closer than the current k-th best one, or within maxRadius. The radius bounds the
+// search when fewer than k items are reachable: at fine precisions the globe holds far too many cells to visit.
+// Returns an error if the coordinates, radius, or precision are invalid, or if fetch fails.
+func KNN[T any](latitude, longitude float64, k int, maxRadius float64, precision Precision, fetch func(hash string) ([]Item[T], error)) ([]Item[T], error) {
+	center, err := Encode(latitude, longitude, precision)
+	if err != nil {
+		return nil, err
+	}
+	if maxRadius < 0 || !isFinite(maxRadius) {
+		return nil, ErrInvalidRadius
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	bitset, _, _ := decodeFromBase32(center)
+	latIndex, lngIndex := splitBitset(bitset, precision)
+	_, _, cell, _ := DecodeBBox(center)
+
+	type candidate struct {
+		item     Item[T]
+		distance float64
+	}
+	var best []candidate
+
+	// Once rings wrap around the antimeridian they overlap earlier ones, so track visits across rings.
+	seen := make(map[int64]bool)
+	for ring := 0; ; ring++ {
+		for _, hash := range ringCells(int64(latIndex), int64(lngIndex), ring, precision, seen) {
+			items, err := fetch(hash)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range items {
+				d := Distance(latitude, longitude, item.Latitude, item.Longitude)
+				if d > maxRadius || len(best) == k && d >= best[k-1].distance {
+					continue
+				}
+
+				i := sort.Search(len(best), func(i int) bool { return best[i].distance > d })
+				best = append(best, candidate{})
+				copy(best[i+1:], best[i:])
+				best[i] = candidate{item: item, distance: d}
+				if len(best) > k {
+					best = best[:k]
+				}
+			}
+		}
+
+		bound := ringBound(latitude, longitude, cell, ring)
+		if bound > maxRadius || (len(best) == k && best[k-1].distance <= bound) {
+			break
+		}
+	}
+
+	result := make([]Item[T], len(best))
+	for i, c := range best {
+		result[i] = c.item
+	}
+	return result, nil
+}
+
+// ringCells returns the cells at Chebyshev distance k from the cell at the given grid indexes,
+// skipping and recording in seen the cells already visited.
+func ringCells(latIndex, lngIndex int64, k int, precision Precision, seen map[int64]bool) []string {
+	totalBits := int(precision) * bitsPerChar
+	rows := int64(1) << (totalBits / 2)
+	columns := int64(1) << (totalBits - totalBits/2)
+
+	var cells []string
+	visit := func(lat, lng int64) {
+		if lat < 0 || lat >= rows {
+			return
+		}
+		lng = ((lng % columns) + columns) % columns
+		if seen[lat*columns+lng] {
+			return
+		}
+		seen[lat*columns+lng] = true
+		cells = append(cells, encodeToBase32(interlaceBitsets(uint64(lat), uint64(lng), precision), precision))
+	}
+
+	r := int64(k)
+	if r == 0 {
+		visit(latIndex, lngIndex)
+		return cells
+	}
+	for d := -r; d <= r; d++ {
+		visit(latIndex+r, lngIndex+d)
+		visit(latIndex-r, lngIndex+d)
+	}
+	for d := -r + 1; d < r; d++ {
+		visit(latIndex+d, lngIndex+r)
+		visit(latIndex+d, lngIndex-r)
+	}
+	return cells
+}
+
+// ringBound returns a lower bound in meters of the distance from the coordinates to any cell lying beyond the
+// given ring around cell, or +Inf if every cell has been visited.
+func ringBound(latitude, longitude float64, cell BBox, ring int) float64 {
+	height := cell.MaxLatitude - cell.MinLatitude
+	width := cell.MaxLongitude - cell.MinLongitude
+	r := float64(ring)
+
+	bound := math.Inf(1)
+	if north := cell.MaxLatitude + r*height; north < maxLatitude {
+		bound = math.Min(bound, degToRad(north-latitude)*earthRadius)
+	}
+	if south := cell.MinLatitude - r*height; south > minLatitude {
+		bound = math.Min(bound, degToRad(latitude-south)*earthRadius)
+	}
+	if (2*r+1)*width < maxLongitude-minLongitude {
+		east := cell.MaxLongitude + r*width - longitude
+		west := longitude - (cell.MinLongitude - r*width)
+		bound = math.Min(bound, meridianDistance(latitude, east))
+		bound = math.Min(bound, meridianDistance(latitude, west))
+	}
+	return bound
+}
+
+// meridianDistance returns the distance in meters from a point at the given latitude to the meridian
+// lying deltaLng degrees away from it.
+func meridianDistance(latitude, deltaLng float64) float64 {
+	if deltaLng >= 90 {
+		return degToRad(maxLatitude-math.Abs(latitude)) * earthRadius
+	}
+	return math.Asin(math.Cos(degToRad(latitude))*math.Sin(degToRad(deltaLng))) * earthRadius
+}
@@ -0,0 +1,162 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRing(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		k       int
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Invalid hash",
+			hash:    "9q8yy!",
+			wantErr: ErrInvalidHashFormat,
+		},
+		{
+			name:    "Invalid ring",
+			hash:    "9q8yy",
+			k:       -1,
+			wantErr: ErrInvalidRadius,
+		},
+		{
+			name: "Ring 0 - the cell itself",
+			hash: "9q8yy",
+			k:    0,
+			want: []string{"9q8yy"},
+		},
+		{
+			name: "Ring 1 - the neighbors",
+			hash: "9q8yy",
+			k:    1,
+			want: MustNeighbors("9q8yy"),
+		},
+		{
+			name: "Ring 1 - stops at the pole and wraps the antimeridian",
+			hash: "z",
+			k:    1,
+			want: []string{"b", "8", "x", "w", "y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Ring(tt.hash, tt.k)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+
+	assert.Len(t, MustRing("9q8yy", 2), 16)
+	assert.Panics(t, func() { MustRing("", 1) })
+}
+
+func TestKNN(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	store := make(map[string][]Item[int])
+	var all []Item[int]
+	for i := 0; i < 500; i++ {
+		item := Item[int]{Latitude: 30 + rng.Float64()*20, Longitude: -130 + rng.Float64()*20, Value: i}
+		hash := MustEncode(item.Latitude, item.Longitude, State)
+		store[hash] = append(store[hash], item)
+		all = append(all, item)
+	}
+	fetch := func(hash string) ([]Item[int], error) { return store[hash], nil }
+
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		k         int
+	}{
+		{name: "Inside the data", latitude: 40, longitude: -120, k: 10},
+		{name: "At the edge of the data", latitude: 30, longitude: -130, k: 5},
+		{name: "Far from the data", latitude: -40, longitude: 60, k: 3},
+		{name: "More than available", latitude: 40, longitude: -120, k: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KNN(tt.latitude, tt.longitude, tt.k, math.Pi*earthRadius, State, fetch)
+			require.NoError(t, err)
+
+			want := append([]Item[int](nil), all...)
+			sort.SliceStable(want, func(a, b int) bool {
+				return Distance(tt.latitude, tt.longitude, want[a].Latitude, want[a].Longitude) <
+					Distance(tt.latitude, tt.longitude, want[b].Latitude, want[b].Longitude)
+			})
+			if len(want) > tt.k {
+				want = want[:tt.k]
+			}
+			assert.Equal(t, itemValues(want), itemValues(got))
+		})
+	}
+}
+
+func TestKNNErrors(t *testing.T) {
+	fetch := func(hash string) ([]Item[int], error) { return nil, nil }
+
+	_, err := KNN(91, 0, 1, 1000, City, fetch)
+	assert.ErrorIs(t, err, ErrLatitudeOutOfRange)
+
+	_, err = KNN(0, 0, 1, 1000, 0, fetch)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	for _, radius := range []float64{-1, math.NaN(), math.Inf(1)} {
+		_, err = KNN(0, 0, 1, radius, City, fetch)
+		assert.ErrorIs(t, err, ErrInvalidRadius)
+	}
+
+	got, err := KNN(0, 0, 0, 1000, City, fetch)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	errFetch := errors.New("fetch failed")
+	_, err = KNN(0, 0, 1, 1000, City, func(hash string) ([]Item[int], error) { return nil, errFetch })
+	assert.ErrorIs(t, err, errFetch)
+
+	got, err = KNN(0, 0, 3, math.Pi*earthRadius, Global, fetch)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestKNNMaxRadius(t *testing.T) {
+	near := Item[int]{Latitude: 40.001, Longitude: -120, Value: 1}
+	far := Item[int]{Latitude: 40.1, Longitude: -120, Value: 2}
+	store := make(map[string][]Item[int])
+	for _, item := range []Item[int]{near, far} {
+		hash := MustEncode(item.Latitude, item.Longitude, Building)
+		store[hash] = append(store[hash], item)
+	}
+	fetch := func(hash string) ([]Item[int], error) { return store[hash], nil }
+
+	tests := []struct {
+		name      string
+		k         int
+		maxRadius float64
+		want      []int
+	}{
+		{name: "Fewer than k within the radius", k: 2, maxRadius: 1000, want: []int{1}},
+		{name: "Fewer than k in the store", k: 3, maxRadius: 20000, want: []int{1, 2}},
+		{name: "Nothing within the radius", k: 1, maxRadius: 10, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KNN(40, -120, tt.k, tt.maxRadius, Building, fetch)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, itemValues(got))
+		})
+	}
+}
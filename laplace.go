@@ -0,0 +1,114 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// ErrInvalidEpsilon is returned when a privacy parameter epsilon is not a positive finite number.
+var ErrInvalidEpsilon = errors.New("invalid epsilon")
+
+// PerturbLaplace displaces the coordinates by planar Laplace noise, providing epsilon-geo-indistinguishability
+// with epsilon expressed per meter: the displacement follows a uniform bearing and a distance whose mean is
+// 2/epsilon. Random numbers are drawn from rng, or from the global math/rand source when rng is nil.
+// Returns an error if the coordinates are out of range or epsilon is invalid.
+func PerturbLaplace(latitude, longitude, epsilon float64, rng *rand.Rand) (float64, float64, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return 0, 0, err
+	}
+	if epsilon <= 0 || !isFinite(epsilon) {
+		return 0, 0, ErrInvalidEpsilon
+	}
+
+	random := rand.Float64
+	if rng != nil {
+		random = rng.Float64
+	}
+
+	bearing := 2 * math.Pi * random()
+	// Inverse of the cumulative distribution of the distance, 1 - (1 + epsilon*r) * exp(-epsilon*r).
+	p := random()
+	distance := -(lambertWm1((p-1)/math.E) + 1) / epsilon
+
+	latitude, longitude = destination(latitude, longitude, bearing, distance)
+	return latitude, longitude, nil
+}
+
+// EncodeLaplace perturbs the coordinates with PerturbLaplace and encodes the result at the given precision.
+// Returns an error if the coordinates, epsilon, or precision is invalid.
+func EncodeLaplace(latitude, longitude, epsilon float64, precision Precision, rng *rand.Rand) (string, error) {
+	if err := checkPrecision(int(precision), int(Global), int(MaxPrecision)); err != nil {
+		return "", err
+	}
+
+	latitude, longitude, err := PerturbLaplace(latitude, longitude, epsilon, rng)
+	if err != nil {
+		return "", err
+	}
+	return Encode(latitude, longitude, precision)
+}
+
+// LaplacePrecision returns the finest precision whose cells are at least as tall as the mean displacement
+// of PerturbLaplace for epsilon, so that encoding finer than the noise does not pretend to more accuracy.
+// Returns an error if epsilon is invalid.
+func LaplacePrecision(epsilon float64) (Precision, error) {
+	if epsilon <= 0 || !isFinite(epsilon) {
+		return 0, ErrInvalidEpsilon
+	}
+
+	scale := 2 / epsilon
+	precision := Global
+	for p := Global + 1; p <= SubPoint; p++ {
+		latBits, _ := axisBits(int(p) * bitsPerChar)
+		height := math.Pi * earthRadius / float64(uint64(1)<<latBits)
+		if height < scale {
+			break
+		}
+		precision = p
+	}
+	return precision, nil
+}
+
+// destination returns the point reached by travelling distance meters from the coordinates along a great
+// circle with the given initial bearing in radians.
+func destination(latitude, longitude, bearing, distance float64) (float64, float64) {
+	phi := degToRad(latitude)
+	delta := distance / earthRadius
+
+	sinPhi := math.Sin(phi)*math.Cos(delta) + math.Cos(phi)*math.Sin(delta)*math.Cos(bearing)
+	sinPhi = math.Max(-1, math.Min(1, sinPhi))
+	lambda := math.Atan2(math.Sin(bearing)*math.Sin(delta)*math.Cos(phi), math.Cos(delta)-math.Sin(phi)*sinPhi)
+
+	return radToDeg(math.Asin(sinPhi)), wrapLongitude(longitude + radToDeg(lambda))
+}
+
+// lambertWm1 returns the lower branch W₋₁ of the Lambert W function for x in [-1/e, 0), refining an
+// asymptotic estimate with Halley's method.
+func lambertWm1(x float64) float64 {
+	if x <= -1/math.E {
+		return -1
+	}
+
+	var w float64
+	if x < -0.25 {
+		// Series around the branch point.
+		p := -math.Sqrt(2 * (math.E*x + 1))
+		w = -1 + p - p*p/3 + 11*p*p*p/72
+	} else {
+		l1 := math.Log(-x)
+		l2 := math.Log(-l1)
+		w = l1 - l2 + l2/l1
+	}
+
+	for i := 0; i < 32; i++ {
+		ew := math.Exp(w)
+		f := w*ew - x
+		dw := f / (ew*(w+1) - (w+2)*f/(2*w+2))
+		w -= dw
+		if math.Abs(dw) <= 1e-14*math.Abs(w) {
+			break
+		}
+	}
+	return w
+}
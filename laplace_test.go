@@ -0,0 +1,137 @@
+package geohash
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerturbLaplace(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		epsilon   float64
+		wantErr   error
+	}{
+		{name: "Valid", latitude: 37.7749, longitude: -122.4194, epsilon: 0.01},
+		{name: "Near the pole", latitude: 89.999, longitude: 0, epsilon: 0.001},
+		{name: "Near the antimeridian", latitude: -16.8, longitude: 179.999, epsilon: 0.001},
+		{name: "Zero epsilon", latitude: 0, longitude: 0, epsilon: 0, wantErr: ErrInvalidEpsilon},
+		{name: "Negative epsilon", latitude: 0, longitude: 0, epsilon: -1, wantErr: ErrInvalidEpsilon},
+		{name: "NaN epsilon", latitude: 0, longitude: 0, epsilon: math.NaN(), wantErr: ErrInvalidEpsilon},
+		{name: "Infinite epsilon", latitude: 0, longitude: 0, epsilon: math.Inf(1), wantErr: ErrInvalidEpsilon},
+		{name: "Latitude out of range", latitude: 91, longitude: 0, epsilon: 1, wantErr: ErrLatitudeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			lat, lng, err := PerturbLaplace(tt.latitude, tt.longitude, tt.epsilon, rng)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, checkCoordinates(lat, lng))
+			assert.NotEqual(t, [2]float64{tt.latitude, tt.longitude}, [2]float64{lat, lng})
+		})
+	}
+}
+
+func TestPerturbLaplaceDistribution(t *testing.T) {
+	const (
+		epsilon = 0.01
+		samples = 20000
+	)
+	rng := rand.New(rand.NewSource(42))
+
+	var sum, north, east float64
+	within := 0
+	for i := 0; i < samples; i++ {
+		lat, lng, err := PerturbLaplace(45, 7, epsilon, rng)
+		require.NoError(t, err)
+
+		d := Distance(45, 7, lat, lng)
+		sum += d
+		if d <= 1/epsilon {
+			within++
+		}
+		north += lat - 45
+		east += lng - 7
+	}
+
+	// The distance follows a Gamma(2, 1/epsilon) distribution, with mean 2/epsilon and
+	// P(r <= 1/epsilon) = 1 - 2/e.
+	assert.InDelta(t, 2/epsilon, sum/samples, 0.02*2/epsilon)
+	assert.InDelta(t, 1-2/math.E, float64(within)/samples, 0.01)
+	// The bearing is uniform, so the displacement is centered.
+	assert.InDelta(t, 0, north/samples, 1e-4)
+	assert.InDelta(t, 0, east/samples, 1e-4)
+}
+
+func TestPerturbLaplaceSeeded(t *testing.T) {
+	lat1, lng1, err := PerturbLaplace(37.7749, -122.4194, 0.01, rand.New(rand.NewSource(3)))
+	require.NoError(t, err)
+	lat2, lng2, err := PerturbLaplace(37.7749, -122.4194, 0.01, rand.New(rand.NewSource(3)))
+	require.NoError(t, err)
+	assert.Equal(t, lat1, lat2)
+	assert.Equal(t, lng1, lng2)
+
+	_, _, err = PerturbLaplace(37.7749, -122.4194, 0.01, nil)
+	assert.NoError(t, err)
+}
+
+func TestEncodeLaplace(t *testing.T) {
+	hash, err := EncodeLaplace(37.7749, -122.4194, 0.001, Street, rand.New(rand.NewSource(3)))
+	require.NoError(t, err)
+	lat, lng, err := PerturbLaplace(37.7749, -122.4194, 0.001, rand.New(rand.NewSource(3)))
+	require.NoError(t, err)
+	assert.Equal(t, MustEncode(lat, lng, Street), hash)
+
+	_, err = EncodeLaplace(37.7749, -122.4194, 0.001, 0, nil)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	_, err = EncodeLaplace(37.7749, -122.4194, 0, Street, nil)
+	assert.ErrorIs(t, err, ErrInvalidEpsilon)
+}
+
+func TestLaplacePrecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		epsilon float64
+		want    Precision
+		wantErr error
+	}{
+		{name: "Continental noise", epsilon: 1e-7, want: Global},
+		{name: "Kilometer noise", epsilon: 0.002, want: City},
+		{name: "Two hundred meters noise", epsilon: 0.01, want: Street},
+		{name: "Two meters noise", epsilon: 1, want: House},
+		{name: "Negligible noise", epsilon: 1e6, want: SubPoint},
+		{name: "Invalid epsilon", epsilon: 0, wantErr: ErrInvalidEpsilon},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LaplacePrecision(tt.epsilon)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLambertWm1(t *testing.T) {
+	assert.Equal(t, -1.0, lambertWm1(-1/math.E))
+	for _, x := range []float64{-0.3678, -0.3, -0.25, -0.1, -1e-3, -1e-10, -1e-300} {
+		w := lambertWm1(x)
+		assert.LessOrEqual(t, w, -1.0)
+		assert.InEpsilon(t, x, w*math.Exp(w), 1e-9, "x = %v", x)
+	}
+}
@@ -0,0 +1,171 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+)
+
+// maidenheadPairs is the number of character pairs of the most precise (extended square) locator.
+const maidenheadPairs = 4
+
+// ErrInvalidLocator is returned when a string is not a valid Maidenhead locator.
+var ErrInvalidLocator = errors.New("invalid locator")
+
+// maidenheadBases are the number of divisions of each axis at every character pair:
+// fields (A-R), squares (0-9), subsquares (a-x) and extended squares (0-9).
+var maidenheadBases = [maidenheadPairs]int{18, 10, 24, 10}
+
+// maidenheadFirst are the first characters of each pair in canonical form.
+var maidenheadFirst = [maidenheadPairs]byte{'A', '0', 'a', '0'}
+
+// EncodeMaidenhead returns the Maidenhead locator of the given coordinates with 2, 4, 6 or 8 characters.
+// Fields are upper-case and subsquares lower-case, as in "JN58td".
+// Returns an error if the coordinates or length are out of range.
+func EncodeMaidenhead(latitude, longitude float64, length int) (string, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
+	}
+	if length < 2 || length > 2*maidenheadPairs || length%2 == 1 {
+		return "", ErrInvalidCodeLength
+	}
+
+	pairs := length / 2
+	units := maidenheadUnits(pairs)
+	lngIndex := min(int(math.Floor((longitude-minLongitude)/(maxLongitude-minLongitude)*float64(units))), units-1)
+	latIndex := min(int(math.Floor((latitude-minLatitude)/(maxLatitude-minLatitude)*float64(units))), units-1)
+	return maidenheadLocator(latIndex, lngIndex, pairs), nil
+}
+
+// MustEncodeMaidenhead returns the Maidenhead locator of the given coordinates or panics if an error occurs.
+func MustEncodeMaidenhead(latitude, longitude float64, length int) string {
+	locator, err := EncodeMaidenhead(latitude, longitude, length)
+	if err != nil {
+		panic(err)
+	}
+	return locator
+}
+
+// DecodeMaidenhead decodes a Maidenhead locator into the center coordinates and bounding box of its square.
+// Letters are accepted in either case.
+// Returns an error if the locator is invalid.
+func DecodeMaidenhead(locator string) (latitude, longitude float64, bbox BBox, err error) {
+	latIndex, lngIndex, pairs, err := parseMaidenhead(locator)
+	if err != nil {
+		return 0, 0, BBox{}, err
+	}
+
+	units := float64(maidenheadUnits(pairs))
+	latStep := (maxLatitude - minLatitude) / units
+	lngStep := (maxLongitude - minLongitude) / units
+	bbox = BBox{
+		MinLatitude:  minLatitude + float64(latIndex)*latStep,
+		MaxLatitude:  minLatitude + float64(latIndex+1)*latStep,
+		MinLongitude: minLongitude + float64(lngIndex)*lngStep,
+		MaxLongitude: minLongitude + float64(lngIndex+1)*lngStep,
+	}
+	return (bbox.MinLatitude + bbox.MaxLatitude) / 2, (bbox.MinLongitude + bbox.MaxLongitude) / 2, bbox, nil
+}
+
+// MustDecodeMaidenhead decodes a Maidenhead locator or panics if an error occurs.
+func MustDecodeMaidenhead(locator string) (latitude, longitude float64, bbox BBox) {
+	lat, lng, bbox, err := DecodeMaidenhead(locator)
+	if err != nil {
+		panic(err)
+	}
+	return lat, lng, bbox
+}
+
+// MaidenheadCover returns the sorted GeoHash cells at the given precision covering a Maidenhead square.
+// Returns an error if the locator or precision is invalid, or if the covering would be too large.
+func MaidenheadCover(locator string, precision Precision) ([]string, error) {
+	_, _, bbox, err := DecodeMaidenhead(locator)
+	if err != nil {
+		return nil, err
+	}
+	return Cover(bbox, precision)
+}
+
+// HashMaidenhead returns the Maidenhead locators of the given length (2, 4, 6 or 8) covering the GeoHash cell,
+// ordered from south-west to north-east.
+// Returns an error if the hash or length is invalid, or if too many locators would be needed.
+func HashMaidenhead(hash string, length int) ([]string, error) {
+	_, _, bbox, err := DecodeBBox(hash)
+	if err != nil {
+		return nil, err
+	}
+	if length < 2 || length > 2*maidenheadPairs || length%2 == 1 {
+		return nil, ErrInvalidCodeLength
+	}
+
+	pairs := length / 2
+	units := maidenheadUnits(pairs)
+	latScale := float64(units) / (maxLatitude - minLatitude)
+	lngScale := float64(units) / (maxLongitude - minLongitude)
+	latLo, latHi := indexSpan((bbox.MinLatitude-minLatitude)*latScale, (bbox.MaxLatitude-minLatitude)*latScale, units-1)
+	lngLo, lngHi := indexSpan((bbox.MinLongitude-minLongitude)*lngScale, (bbox.MaxLongitude-minLongitude)*lngScale, units-1)
+	if (latHi-latLo+1)*(lngHi-lngLo+1) > maxCoverCells {
+		return nil, ErrCoverTooLarge
+	}
+
+	locators := make([]string, 0, (latHi-latLo+1)*(lngHi-lngLo+1))
+	for lat := latLo; lat <= latHi; lat++ {
+		for lng := lngLo; lng <= lngHi; lng++ {
+			locators = append(locators, maidenheadLocator(lat, lng, pairs))
+		}
+	}
+	return locators, nil
+}
+
+// maidenheadUnits returns the number of divisions of each axis after the given number of character pairs.
+func maidenheadUnits(pairs int) int {
+	units := 1
+	for _, base := range maidenheadBases[:pairs] {
+		units *= base
+	}
+	return units
+}
+
+// maidenheadLocator formats the square at the given axis indexes, expressed in units of the last pair.
+func maidenheadLocator(latIndex, lngIndex, pairs int) string {
+	locator := make([]byte, 2*pairs)
+	for i := pairs - 1; i >= 0; i-- {
+		base := maidenheadBases[i]
+		locator[2*i] = maidenheadFirst[i] + byte(lngIndex%base)
+		locator[2*i+1] = maidenheadFirst[i] + byte(latIndex%base)
+		lngIndex /= base
+		latIndex /= base
+	}
+	return string(locator)
+}
+
+// parseMaidenhead returns the axis indexes and pair count of a locator.
+func parseMaidenhead(locator string) (latIndex, lngIndex, pairs int, err error) {
+	if len(locator) < 2 || len(locator) > 2*maidenheadPairs || len(locator)%2 == 1 {
+		return 0, 0, 0, ErrInvalidLocator
+	}
+
+	pairs = len(locator) / 2
+	for i := 0; i < pairs; i++ {
+		base := maidenheadBases[i]
+		lng, lat := maidenheadDigit(locator[2*i], i), maidenheadDigit(locator[2*i+1], i)
+		if lng < 0 || lng >= base || lat < 0 || lat >= base {
+			return 0, 0, 0, ErrInvalidLocator
+		}
+		lngIndex = lngIndex*base + lng
+		latIndex = latIndex*base + lat
+	}
+	return latIndex, lngIndex, pairs, nil
+}
+
+// maidenheadDigit returns the value of a locator character at the given pair, or -1 if it is not a digit
+// or letter of the expected kind.
+func maidenheadDigit(c byte, pair int) int {
+	first := maidenheadFirst[pair]
+	if first != '0' {
+		c = upperASCII(c)
+		first = 'A'
+	} else if c < '0' || c > '9' {
+		return -1
+	}
+	return int(c) - int(first)
+}
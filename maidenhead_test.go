@@ -0,0 +1,178 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMaidenhead(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		length    int
+		want      string
+		wantErr   error
+	}{
+		{
+			name:     "Latitude out of range",
+			latitude: -91,
+			length:   6,
+			wantErr:  ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Longitude out of range",
+			longitude: 181,
+			length:    6,
+			wantErr:   ErrLongitudeOutOfRange,
+		},
+		{
+			name:    "Invalid length",
+			length:  5,
+			wantErr: ErrInvalidCodeLength,
+		},
+		{
+			name:      "Field",
+			latitude:  48.14666,
+			longitude: 11.60833,
+			length:    2,
+			want:      "JN",
+		},
+		{
+			name:      "Subsquare",
+			latitude:  48.14666,
+			longitude: 11.60833,
+			length:    6,
+			want:      "JN58td",
+		},
+		{
+			name:      "Extended square",
+			latitude:  48.14666,
+			longitude: 11.60833,
+			length:    8,
+			want:      "JN58td25",
+		},
+		{
+			name:      "Southern hemisphere",
+			latitude:  -34.91,
+			longitude: -56.21166,
+			length:    6,
+			want:      "GF15vc",
+		},
+		{
+			name:      "North-east corner",
+			latitude:  90,
+			longitude: 180,
+			length:    8,
+			want:      "RR99xx99",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeMaidenhead(tt.latitude, tt.longitude, tt.length)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeMaidenhead(t *testing.T) {
+	tests := []struct {
+		locator string
+		want    BBox
+		wantErr error
+	}{
+		{locator: "JN", want: BBox{MinLatitude: 40, MaxLatitude: 50, MinLongitude: 0, MaxLongitude: 20}},
+		{locator: "JN58", want: BBox{MinLatitude: 48, MaxLatitude: 49, MinLongitude: 10, MaxLongitude: 12}},
+		{locator: "jn58TD", want: BBox{MinLatitude: 48.125, MaxLatitude: 48.125 + 1.0/24, MinLongitude: 11.5 + 1.0/12, MaxLongitude: 11.5 + 2.0/12}},
+		{locator: "", wantErr: ErrInvalidLocator},
+		{locator: "JN5", wantErr: ErrInvalidLocator},
+		{locator: "SN58", wantErr: ErrInvalidLocator},
+		{locator: "JNA8", wantErr: ErrInvalidLocator},
+		{locator: "JN58ty", wantErr: ErrInvalidLocator},
+		{locator: "JN58td25aa", wantErr: ErrInvalidLocator},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locator, func(t *testing.T) {
+			lat, lng, bbox, err := DecodeMaidenhead(tt.locator)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.InDelta(t, tt.want.MinLatitude, bbox.MinLatitude, tolerance)
+			assert.InDelta(t, tt.want.MaxLatitude, bbox.MaxLatitude, tolerance)
+			assert.InDelta(t, tt.want.MinLongitude, bbox.MinLongitude, tolerance)
+			assert.InDelta(t, tt.want.MaxLongitude, bbox.MaxLongitude, tolerance)
+			assert.InDelta(t, (tt.want.MinLatitude+tt.want.MaxLatitude)/2, lat, tolerance)
+			assert.InDelta(t, (tt.want.MinLongitude+tt.want.MaxLongitude)/2, lng, tolerance)
+		})
+	}
+
+	// Decoding the center of a square encodes back to the same locator.
+	for _, locator := range []string{"AA", "RR99", "GF15vc", "JN58td25"} {
+		lat, lng, _ := MustDecodeMaidenhead(locator)
+		assert.Equal(t, locator, MustEncodeMaidenhead(lat, lng, len(locator)))
+	}
+}
+
+func TestMaidenheadCover(t *testing.T) {
+	got, err := MaidenheadCover("JN58td", City)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u281z", "u283b", "u283c", "u284p", "u2860", "u2861"}, got)
+
+	_, err = MaidenheadCover("JN58t", City)
+	assert.ErrorIs(t, err, ErrInvalidLocator)
+}
+
+func TestHashMaidenhead(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		length  int
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Invalid hash",
+			hash:    "u281z!",
+			length:  6,
+			wantErr: ErrInvalidHashFormat,
+		},
+		{
+			name:    "Invalid length",
+			hash:    "u281z",
+			length:  10,
+			wantErr: ErrInvalidCodeLength,
+		},
+		{
+			name:    "Too many locators",
+			hash:    "u",
+			length:  8,
+			wantErr: ErrCoverTooLarge,
+		},
+		{
+			name:   "Cell within one field",
+			hash:   "u281z",
+			length: 2,
+			want:   []string{"JN"},
+		},
+		{
+			name:   "Cell spanning several subsquares",
+			hash:   "u281z",
+			length: 6,
+			want:   []string{"JN58sc", "JN58tc", "JN58sd", "JN58td"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HashMaidenhead(tt.hash, tt.length)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package geohash
+
+import "math"
+
+// Normalization selects how EncodeWithOptions treats coordinates outside the valid ranges.
+type Normalization int
+
+const (
+	// NormalizeStrict rejects out-of-range coordinates, as Encode does.
+	NormalizeStrict Normalization = iota
+
+	// NormalizeClamp moves out-of-range coordinates to the nearest valid value, absorbing GPS noise near
+	// the poles and the antimeridian.
+	NormalizeClamp
+
+	// NormalizeWrap wraps longitudes around the globe and carries latitudes beyond a pole over to the
+	// other side of it, shifting the longitude by 180 degrees.
+	NormalizeWrap
+)
+
+// EncodeOptions configures EncodeWithOptions.
+type EncodeOptions struct {
+	// Normalization is applied to out-of-range coordinates. The zero value is NormalizeStrict.
+	Normalization Normalization
+}
+
+// EncodeWithOptions generates a GeoHash string like Encode, first normalizing out-of-range coordinates
+// as configured. NaN and infinite coordinates are always rejected.
+// Returns an error if a coordinate is not finite, is out of range in strict mode, or if the precision is invalid.
+func EncodeWithOptions(latitude, longitude float64, precision Precision, opts EncodeOptions) (string, error) {
+	latitude, longitude, err := NormalizeCoordinates(latitude, longitude, opts.Normalization)
+	if err != nil {
+		return "", err
+	}
+	return Encode(latitude, longitude, precision)
+}
+
+// MustEncodeWithOptions generates a GeoHash string with the given options or panics if an error occurs.
+func MustEncodeWithOptions(latitude, longitude float64, precision Precision, opts EncodeOptions) string {
+	hash, err := EncodeWithOptions(latitude, longitude, precision, opts)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// NormalizeCoordinates returns the coordinates brought into the valid ranges with the given normalization.
+// Coordinates already in range are returned unchanged.
+// Returns an error if a coordinate is not finite, or is out of range in strict mode.
+func NormalizeCoordinates(latitude, longitude float64, normalization Normalization) (float64, float64, error) {
+	if !isFinite(latitude) || !isFinite(longitude) {
+		return 0, 0, checkCoordinates(latitude, longitude)
+	}
+
+	switch normalization {
+	case NormalizeClamp:
+		latitude = math.Max(minLatitude, math.Min(maxLatitude, latitude))
+		longitude = math.Max(minLongitude, math.Min(maxLongitude, longitude))
+	case NormalizeWrap:
+		latitude, longitude = wrapPole(latitude, longitude)
+		longitude = wrapLongitude(longitude)
+	}
+
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return 0, 0, err
+	}
+	return latitude, longitude, nil
+}
+
+// wrapPole carries a latitude beyond a pole over to the other side of it, on the opposite meridian.
+func wrapPole(latitude, longitude float64) (float64, float64) {
+	if latitude >= minLatitude && latitude <= maxLatitude {
+		return latitude, longitude
+	}
+
+	// Bring the latitude into [-180, 180) around the full meridian circle, then fold it over the pole.
+	latitude, _ = wrapCoordinates(latitude/2, 0)
+	latitude *= 2
+	switch {
+	case latitude > maxLatitude:
+		return 2*maxLatitude - latitude, longitude + 2*maxLatitude
+	case latitude < minLatitude:
+		return 2*minLatitude - latitude, longitude + 2*maxLatitude
+	}
+	return latitude, longitude
+}
@@ -0,0 +1,119 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCoordinates(t *testing.T) {
+	tests := []struct {
+		name          string
+		latitude      float64
+		longitude     float64
+		normalization Normalization
+		wantLatitude  float64
+		wantLongitude float64
+		wantErr       error
+	}{
+		{name: "Strict in range", latitude: 45, longitude: 90, wantLatitude: 45, wantLongitude: 90},
+		{name: "Strict latitude", latitude: 90.0001, normalization: NormalizeStrict, wantErr: ErrLatitudeOutOfRange},
+		{name: "Strict longitude", longitude: 181, normalization: NormalizeStrict, wantErr: ErrLongitudeOutOfRange},
+		{name: "Clamp in range", latitude: -12.5, longitude: 33, normalization: NormalizeClamp, wantLatitude: -12.5, wantLongitude: 33},
+		{name: "Clamp north", latitude: 90.0001, longitude: 181, normalization: NormalizeClamp, wantLatitude: 90, wantLongitude: 180},
+		{name: "Clamp south", latitude: -91, longitude: -200, normalization: NormalizeClamp, wantLatitude: -90, wantLongitude: -180},
+		{name: "Wrap in range", latitude: 90, longitude: 180, normalization: NormalizeWrap, wantLatitude: 90, wantLongitude: 180},
+		{name: "Wrap longitude", latitude: 10, longitude: 181, normalization: NormalizeWrap, wantLatitude: 10, wantLongitude: -179},
+		{name: "Wrap several turns", longitude: -900, normalization: NormalizeWrap, wantLatitude: 0, wantLongitude: -180},
+		{name: "Wrap over the north pole", latitude: 91, longitude: 10, normalization: NormalizeWrap, wantLatitude: 89, wantLongitude: -170},
+		{name: "Wrap over the south pole", latitude: -95, longitude: -100, normalization: NormalizeWrap, wantLatitude: -85, wantLongitude: 80},
+		{name: "Wrap to the opposite pole", latitude: 270, normalization: NormalizeWrap, wantLatitude: -90, wantLongitude: 0},
+		{name: "NaN latitude", latitude: math.NaN(), normalization: NormalizeClamp, wantErr: ErrInvalidCoordinate},
+		{name: "Infinite longitude", longitude: math.Inf(1), normalization: NormalizeWrap, wantErr: ErrInvalidCoordinate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lng, err := NormalizeCoordinates(tt.latitude, tt.longitude, tt.normalization)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.InDelta(t, tt.wantLatitude, lat, tolerance)
+			assert.InDelta(t, tt.wantLongitude, lng, tolerance)
+		})
+	}
+}
+
+func TestEncodeWithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		precision Precision
+		opts      EncodeOptions
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "Strict by default",
+			latitude:  37.7749,
+			longitude: -482.4194,
+			precision: City,
+			wantErr:   ErrLongitudeOutOfRange,
+		},
+		{
+			name:      "Wrapped longitude",
+			latitude:  37.7749,
+			longitude: -482.4194,
+			precision: City,
+			opts:      EncodeOptions{Normalization: NormalizeWrap},
+			want:      "9q8yy",
+		},
+		{
+			name:      "Clamped GPS noise",
+			latitude:  90.00001,
+			longitude: 180.00001,
+			precision: City,
+			opts:      EncodeOptions{Normalization: NormalizeClamp},
+			want:      "zzzzz",
+		},
+		{
+			name:      "Invalid precision",
+			precision: 0,
+			opts:      EncodeOptions{Normalization: NormalizeClamp},
+			wantErr:   ErrPrecisionOutOfRange,
+		},
+		{
+			name:      "NaN",
+			latitude:  math.NaN(),
+			precision: City,
+			opts:      EncodeOptions{Normalization: NormalizeWrap},
+			wantErr:   ErrInvalidCoordinate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeWithOptions(tt.latitude, tt.longitude, tt.precision, tt.opts)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, "9q8yy", MustEncodeWithOptions(37.7749, 237.5806, City, EncodeOptions{Normalization: NormalizeWrap}))
+	assert.Panics(t, func() { MustEncodeWithOptions(91, 0, City, EncodeOptions{}) })
+}
+
+func TestEncodeRejectsNonFinite(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := Encode(v, 0, City)
+		assert.ErrorIs(t, err, ErrInvalidCoordinate)
+		_, err = Encode(0, v, City)
+		assert.ErrorIs(t, err, ErrInvalidCoordinate)
+	}
+
+	_, err := Cover(BBox{MinLatitude: 0, MaxLatitude: math.NaN()}, City)
+	assert.ErrorIs(t, err, ErrInvalidCoordinate)
+	_, err = EncodeUTM(math.NaN(), 0)
+	assert.ErrorIs(t, err, ErrInvalidCoordinate)
+	assert.EqualError(t, checkCoordinates(0, math.Inf(-1)), "invalid coordinate: longitude is -Inf")
+}
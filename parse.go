@@ -0,0 +1,64 @@
+package geohash
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ambiguousChars are the letters left out of the GeoHash alphabet because they are easily confused with digits.
+const ambiguousChars = "ailo"
+
+// ParseOptions configures how lenient Parse is with hashes coming from user input.
+type ParseOptions struct {
+	// FoldCase accepts upper-case ASCII letters.
+	FoldCase bool
+	// TrimSpace ignores leading and trailing white space.
+	TrimSpace bool
+	// IgnoreAmbiguous drops the letters a, i, l and o instead of rejecting them.
+	IgnoreAmbiguous bool
+}
+
+// Parse returns the canonical form of a GeoHash string after applying the given options.
+// Returns a *FormatError for the first invalid character, or an error if the resulting hash is empty
+// or longer than MaxPrecision.
+func Parse(hash string, opts ParseOptions) (string, error) {
+	offset := 0
+	if opts.TrimSpace {
+		trimmed := strings.TrimLeftFunc(hash, unicode.IsSpace)
+		offset = len(hash) - len(trimmed)
+		hash = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(hash))
+	for i, char := range hash {
+		if opts.FoldCase && char >= 'A' && char <= 'Z' {
+			char += 'a' - 'A'
+		}
+		if _, ok := StdEncoding.value(char); ok {
+			sb.WriteRune(char)
+			continue
+		}
+		if opts.IgnoreAmbiguous && char < utf8.RuneSelf && strings.IndexByte(ambiguousChars, byte(char)) >= 0 {
+			continue
+		}
+
+		r, _ := utf8.DecodeRuneInString(hash[i:])
+		return "", &FormatError{Position: offset + i, Char: r}
+	}
+
+	if err := checkHashLength(sb.Len(), MaxPrecision); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// MustParse returns the canonical form of a GeoHash string or panics if an error occurs.
+func MustParse(hash string, opts ParseOptions) string {
+	canonical, err := Parse(hash, opts)
+	if err != nil {
+		panic(err)
+	}
+	return canonical
+}
@@ -0,0 +1,133 @@
+package geohash
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	lenient := ParseOptions{FoldCase: true, TrimSpace: true, IgnoreAmbiguous: true}
+
+	tests := []struct {
+		name      string
+		hash      string
+		opts      ParseOptions
+		want      string
+		wantErr   error
+		wantError *FormatError
+	}{
+		{
+			name: "Canonical hash",
+			hash: "9q8yy",
+			want: "9q8yy",
+		},
+		{
+			name:      "Upper-case rejected by default",
+			hash:      "9Q8yy",
+			wantErr:   ErrInvalidHashFormat,
+			wantError: &FormatError{Position: 1, Char: 'Q'},
+		},
+		{
+			name: "Upper-case folded",
+			hash: "9Q8YY",
+			opts: ParseOptions{FoldCase: true},
+			want: "9q8yy",
+		},
+		{
+			name:      "White space rejected by default",
+			hash:      " 9q8yy",
+			wantErr:   ErrInvalidHashFormat,
+			wantError: &FormatError{Position: 0, Char: ' '},
+		},
+		{
+			name: "White space trimmed",
+			hash: "\t9q8yy \n",
+			opts: ParseOptions{TrimSpace: true},
+			want: "9q8yy",
+		},
+		{
+			name:      "Inner white space rejected",
+			hash:      "  9q8 yy",
+			opts:      ParseOptions{TrimSpace: true},
+			wantErr:   ErrInvalidHashFormat,
+			wantError: &FormatError{Position: 5, Char: ' '},
+		},
+		{
+			name:      "Ambiguous letter rejected",
+			hash:      "9q8ly",
+			wantErr:   ErrInvalidHashFormat,
+			wantError: &FormatError{Position: 3, Char: 'l'},
+		},
+		{
+			name: "Ambiguous letters ignored",
+			hash: "9aq8yiyo",
+			opts: ParseOptions{IgnoreAmbiguous: true},
+			want: "9q8yy",
+		},
+		{
+			name:      "Upper-case ambiguous letter needs folding",
+			hash:      "9q8Ly",
+			opts:      ParseOptions{IgnoreAmbiguous: true},
+			wantErr:   ErrInvalidHashFormat,
+			wantError: &FormatError{Position: 3, Char: 'L'},
+		},
+		{
+			name: "All options",
+			hash: " 9Q8YOY ",
+			opts: lenient,
+			want: "9q8yy",
+		},
+		{
+			name:      "Non-ASCII character",
+			hash:      "9q8é",
+			opts:      lenient,
+			wantErr:   ErrInvalidHashFormat,
+			wantError: &FormatError{Position: 3, Char: 'é'},
+		},
+		{
+			name:    "Empty after trimming",
+			hash:    "   ",
+			opts:    lenient,
+			wantErr: ErrInvalidHashLength,
+		},
+		{
+			name:    "Only ambiguous letters",
+			hash:    "ail",
+			opts:    lenient,
+			wantErr: ErrInvalidHashLength,
+		},
+		{
+			name:    "Too long",
+			hash:    "9q8yyk8ytpxrs9q8yyk8ytpxrs",
+			wantErr: ErrInvalidHashLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.hash, tt.opts)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantError != nil {
+				var formatErr *FormatError
+				require.True(t, errors.As(err, &formatErr))
+				assert.Equal(t, tt.wantError, formatErr)
+			}
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	err := &FormatError{Position: 3, Char: 'l'}
+	assert.Equal(t, `invalid hash format: character 'l' at position 3`, err.Error())
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+}
+
+func TestMustParse(t *testing.T) {
+	assert.Equal(t, "9q8yy", MustParse("9Q8YY", ParseOptions{FoldCase: true}))
+	assert.Panics(t, func() { MustParse("9Q8YY", ParseOptions{}) })
+}
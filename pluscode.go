@@ -0,0 +1,363 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	plusCodeAlphabet      = "23456789CFGHJMPQRVWX"
+	plusCodeSeparator     = '+'
+	plusCodePadding       = '0'
+	plusCodeSeparatorPos  = 8
+	plusCodeBase          = 20
+	plusCodeMaxDigits     = 15
+	plusCodePairLength    = 10
+	plusCodeGridLength    = plusCodeMaxDigits - plusCodePairLength
+	plusCodeGridColumns   = 4
+	plusCodeGridRows      = 5
+	plusCodeMinTrimLength = 6
+
+	// plusCodePairPrecision is the number of pair steps per degree at the last pair digit (20^3).
+	plusCodePairPrecision = 8000
+	// plusCodePairFirstValue is the place value of the first pair digit (20^4).
+	plusCodePairFirstValue = 160000
+	// plusCodeGridLatFull and plusCodeGridLngFull are the number of grid steps within a pair cell (5^5 and 4^5).
+	plusCodeGridLatFull = 3125
+	plusCodeGridLngFull = 1024
+	// plusCodeLatPrecision and plusCodeLngPrecision are the number of steps per degree at the last grid digit.
+	plusCodeLatPrecision = plusCodePairPrecision * plusCodeGridLatFull
+	plusCodeLngPrecision = plusCodePairPrecision * plusCodeGridLngFull
+)
+
+var (
+	// ErrInvalidPlusCode is returned when a string is not a valid Open Location Code for the requested use.
+	ErrInvalidPlusCode = errors.New("invalid plus code")
+
+	// ErrInvalidCodeLength is returned when a code length is not supported by the requested grid system.
+	ErrInvalidCodeLength = errors.New("invalid code length")
+)
+
+// plusCodeResolutions are the cell sizes in degrees after each pair of digits.
+var plusCodeResolutions = [...]float64{20, 1, 0.05, 0.0025, 0.000125}
+
+// EncodePlusCode returns the full Open Location Code (Plus Code) of the given coordinates.
+// The code length counts digits, excluding the separator and padding: 2, 4, 6, 8, or any length from 10 to 15.
+// Returns an error if the coordinates or code length are out of range.
+func EncodePlusCode(latitude, longitude float64, codeLength int) (string, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
+	}
+	if codeLength < 2 || codeLength > plusCodeMaxDigits || (codeLength < plusCodePairLength && codeLength%2 == 1) {
+		return "", ErrInvalidCodeLength
+	}
+
+	if longitude == maxLongitude {
+		longitude = minLongitude
+	}
+	if latitude == maxLatitude {
+		latitude -= plusCodeLatitudeStep(codeLength)
+	}
+
+	latValue := int64(math.Round((latitude-minLatitude)*plusCodeLatPrecision*1e6) / 1e6)
+	lngValue := int64(math.Round((longitude-minLongitude)*plusCodeLngPrecision*1e6) / 1e6)
+
+	var code [plusCodeMaxDigits]byte
+	if codeLength > plusCodePairLength {
+		for i := plusCodeMaxDigits - 1; i >= plusCodePairLength; i-- {
+			code[i] = plusCodeAlphabet[(latValue%plusCodeGridRows)*plusCodeGridColumns+lngValue%plusCodeGridColumns]
+			latValue /= plusCodeGridRows
+			lngValue /= plusCodeGridColumns
+		}
+	} else {
+		latValue /= plusCodeGridLatFull
+		lngValue /= plusCodeGridLngFull
+	}
+	for i := plusCodePairLength - 1; i > 0; i -= 2 {
+		code[i] = plusCodeAlphabet[lngValue%plusCodeBase]
+		code[i-1] = plusCodeAlphabet[latValue%plusCodeBase]
+		latValue /= plusCodeBase
+		lngValue /= plusCodeBase
+	}
+
+	if codeLength >= plusCodeSeparatorPos {
+		return string(code[:plusCodeSeparatorPos]) + string(plusCodeSeparator) + string(code[plusCodeSeparatorPos:codeLength]), nil
+	}
+	return string(code[:codeLength]) + strings.Repeat(string(plusCodePadding), plusCodeSeparatorPos-codeLength) +
+		string(plusCodeSeparator), nil
+}
+
+// MustEncodePlusCode returns the Plus Code of the given coordinates or panics if an error occurs.
+func MustEncodePlusCode(latitude, longitude float64, codeLength int) string {
+	code, err := EncodePlusCode(latitude, longitude, codeLength)
+	if err != nil {
+		panic(err)
+	}
+	return code
+}
+
+// DecodePlusCode decodes a full Plus Code into the center coordinates and bounding box of its area.
+// Short codes must be recovered with RecoverPlusCode first.
+// Returns an error if the code is not a valid full code.
+func DecodePlusCode(code string) (latitude, longitude float64, bbox BBox, err error) {
+	if !isFullPlusCode(code) {
+		return 0, 0, BBox{}, ErrInvalidPlusCode
+	}
+
+	digits := plusCodeDigits(code)
+	bbox = decodePlusCodeDigits(digits)
+	return (bbox.MinLatitude + bbox.MaxLatitude) / 2, (bbox.MinLongitude + bbox.MaxLongitude) / 2, bbox, nil
+}
+
+// MustDecodePlusCode decodes a full Plus Code or panics if an error occurs.
+func MustDecodePlusCode(code string) (latitude, longitude float64, bbox BBox) {
+	lat, lng, bbox, err := DecodePlusCode(code)
+	if err != nil {
+		panic(err)
+	}
+	return lat, lng, bbox
+}
+
+// ShortenPlusCode removes as many leading digits from a full Plus Code as the reference location allows,
+// so that RecoverPlusCode with a nearby reference yields the original code.
+// Returns an error if the code is not a full, unpadded code of at least 6 digits.
+func ShortenPlusCode(code string, latitude, longitude float64) (string, error) {
+	if !isFullPlusCode(code) || strings.IndexByte(code, plusCodePadding) >= 0 {
+		return "", ErrInvalidPlusCode
+	}
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
+	}
+
+	code = strings.ToUpper(code)
+	if len(plusCodeDigits(code)) < plusCodeMinTrimLength {
+		return "", ErrInvalidPlusCode
+	}
+	centerLat, centerLng, _, _ := DecodePlusCode(code)
+
+	distance := math.Max(math.Abs(centerLat-latitude), math.Abs(centerLng-longitude))
+	for i := len(plusCodeResolutions) - 2; i >= 1; i-- {
+		// Shorten only well within half the resolution to leave a safety margin.
+		if distance < plusCodeResolutions[i]*0.3 {
+			return code[(i+1)*2:], nil
+		}
+	}
+	return code, nil
+}
+
+// RecoverPlusCode returns the full Plus Code nearest to the reference location matching a short code.
+// Full codes are returned unchanged (upper-cased).
+// Returns an error if the code is neither a valid full nor short code.
+func RecoverPlusCode(code string, latitude, longitude float64) (string, error) {
+	if isFullPlusCode(code) {
+		return strings.ToUpper(code), nil
+	}
+	if !isShortPlusCode(code) {
+		return "", ErrInvalidPlusCode
+	}
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
+	}
+
+	code = strings.ToUpper(code)
+	paddingLength := plusCodeSeparatorPos - strings.IndexByte(code, plusCodeSeparator)
+	resolution := math.Pow(plusCodeBase, float64(2-paddingLength/2))
+	half := resolution / 2
+
+	reference := MustEncodePlusCode(latitude, longitude, plusCodePairLength)
+	digits := plusCodeDigits(reference[:paddingLength] + code)
+	area := decodePlusCodeDigits(digits)
+	centerLat := (area.MinLatitude + area.MaxLatitude) / 2
+	centerLng := (area.MinLongitude + area.MaxLongitude) / 2
+
+	// Move the area by one resolution step if the reference is closer to a neighboring cell.
+	if latitude+half < centerLat && centerLat-resolution >= minLatitude {
+		centerLat -= resolution
+	} else if latitude-half > centerLat && centerLat+resolution <= maxLatitude {
+		centerLat += resolution
+	}
+	if longitude+half < centerLng {
+		centerLng -= resolution
+	} else if longitude-half > centerLng {
+		centerLng += resolution
+	}
+
+	return EncodePlusCode(centerLat, wrapLongitude(centerLng), len(digits))
+}
+
+// PlusCodeCover returns the sorted GeoHash cells at the given precision covering the area of a full Plus Code.
+// Returns an error if the code or precision is invalid, or if the covering would be too large.
+func PlusCodeCover(code string, precision Precision) ([]string, error) {
+	_, _, bbox, err := DecodePlusCode(code)
+	if err != nil {
+		return nil, err
+	}
+	return Cover(bbox, precision)
+}
+
+// HashPlusCodes returns the Plus Codes of the given length (2, 4, 6, 8 or 10) covering the GeoHash cell,
+// ordered from south-west to north-east.
+// Returns an error if the hash or code length is invalid, or if too many codes would be needed.
+func HashPlusCodes(hash string, codeLength int) ([]string, error) {
+	_, _, bbox, err := DecodeBBox(hash)
+	if err != nil {
+		return nil, err
+	}
+	if codeLength < 2 || codeLength > plusCodePairLength || codeLength%2 == 1 {
+		return nil, ErrInvalidCodeLength
+	}
+
+	size := plusCodeResolutions[codeLength/2-1]
+	latLo, latHi := indexSpan((bbox.MinLatitude-minLatitude)/size, (bbox.MaxLatitude-minLatitude)/size,
+		int(math.Round((maxLatitude-minLatitude)/size))-1)
+	lngLo, lngHi := indexSpan((bbox.MinLongitude-minLongitude)/size, (bbox.MaxLongitude-minLongitude)/size,
+		int(math.Round((maxLongitude-minLongitude)/size))-1)
+	if (latHi-latLo+1)*(lngHi-lngLo+1) > maxCoverCells {
+		return nil, ErrCoverTooLarge
+	}
+
+	codes := make([]string, 0, (latHi-latLo+1)*(lngHi-lngLo+1))
+	for lat := latLo; lat <= latHi; lat++ {
+		for lng := lngLo; lng <= lngHi; lng++ {
+			centerLat := minLatitude + (float64(lat)+0.5)*size
+			centerLng := minLongitude + (float64(lng)+0.5)*size
+			codes = append(codes, MustEncodePlusCode(centerLat, centerLng, codeLength))
+		}
+	}
+	return codes, nil
+}
+
+// plusCodeLatitudeStep returns the height in degrees of a code cell of the given length.
+func plusCodeLatitudeStep(codeLength int) float64 {
+	if codeLength <= plusCodePairLength {
+		return math.Pow(plusCodeBase, float64(codeLength/-2+2))
+	}
+	return math.Pow(plusCodeBase, -3) / math.Pow(plusCodeGridRows, float64(codeLength-plusCodePairLength))
+}
+
+// plusCodeDigits returns the upper-cased significant digits of a code, without separator and padding.
+func plusCodeDigits(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.Replace(code, string(plusCodeSeparator), "", 1)
+	code = strings.TrimRight(code, string(plusCodePadding))
+	if len(code) > plusCodeMaxDigits {
+		code = code[:plusCodeMaxDigits]
+	}
+	return code
+}
+
+// decodePlusCodeDigits returns the area of a full code given its significant digits.
+func decodePlusCodeDigits(digits string) BBox {
+	pairDigits := min(len(digits), plusCodePairLength)
+
+	latValue := int64(minLatitude * plusCodePairPrecision)
+	lngValue := int64(minLongitude * plusCodePairPrecision)
+	place := int64(plusCodePairFirstValue)
+	for i := 0; i < pairDigits; i += 2 {
+		latValue += int64(strings.IndexByte(plusCodeAlphabet, digits[i])) * place
+		lngValue += int64(strings.IndexByte(plusCodeAlphabet, digits[i+1])) * place
+		if i < pairDigits-2 {
+			place /= plusCodeBase
+		}
+	}
+	latStep := float64(place) / plusCodePairPrecision
+	lngStep := float64(place) / plusCodePairPrecision
+
+	var gridLat, gridLng int64
+	if len(digits) > plusCodePairLength {
+		rowPlace := int64(plusCodeGridLatFull / plusCodeGridRows)
+		columnPlace := int64(plusCodeGridLngFull / plusCodeGridColumns)
+		for i := plusCodePairLength; i < len(digits); i++ {
+			value := int64(strings.IndexByte(plusCodeAlphabet, digits[i]))
+			gridLat += value / plusCodeGridColumns * rowPlace
+			gridLng += value % plusCodeGridColumns * columnPlace
+			if i < len(digits)-1 {
+				rowPlace /= plusCodeGridRows
+				columnPlace /= plusCodeGridColumns
+			}
+		}
+		latStep = float64(rowPlace) / plusCodeLatPrecision
+		lngStep = float64(columnPlace) / plusCodeLngPrecision
+	}
+
+	latitude := float64(latValue)/plusCodePairPrecision + float64(gridLat)/plusCodeLatPrecision
+	longitude := float64(lngValue)/plusCodePairPrecision + float64(gridLng)/plusCodeLngPrecision
+	return BBox{
+		MinLatitude:  roundPlusCode(latitude),
+		MaxLatitude:  math.Min(maxLatitude, roundPlusCode(latitude+latStep)),
+		MinLongitude: roundPlusCode(longitude),
+		MaxLongitude: math.Min(maxLongitude, roundPlusCode(longitude+lngStep)),
+	}
+}
+
+// roundPlusCode removes floating point noise from decoded coordinates, as the reference implementation does.
+func roundPlusCode(v float64) float64 {
+	return math.Round(v*1e14) / 1e14
+}
+
+// isValidPlusCode reports whether code is a syntactically valid full or short Plus Code.
+func isValidPlusCode(code string) bool {
+	sep := strings.IndexByte(code, plusCodeSeparator)
+	if sep < 0 || sep != strings.LastIndexByte(code, plusCodeSeparator) || sep > plusCodeSeparatorPos || sep%2 == 1 {
+		return false
+	}
+
+	if pad := strings.IndexByte(code, plusCodePadding); pad >= 0 {
+		// Padded codes cannot be short, must pad whole pairs, and cannot have digits after the separator.
+		if sep < plusCodeSeparatorPos || pad == 0 || pad%2 == 1 || len(code) > sep+1 {
+			return false
+		}
+		if strings.Trim(code[pad:sep], string(plusCodePadding)) != "" {
+			return false
+		}
+	}
+
+	if len(code)-sep-1 == 1 {
+		return false
+	}
+
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		if c == plusCodeSeparator || c == plusCodePadding {
+			continue
+		}
+		if strings.IndexByte(plusCodeAlphabet, upperASCII(c)) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isFullPlusCode reports whether code is a valid full Plus Code within the valid coordinate ranges.
+func isFullPlusCode(code string) bool {
+	if !isValidPlusCode(code) || strings.IndexByte(code, plusCodeSeparator) != plusCodeSeparatorPos {
+		return false
+	}
+
+	first := strings.IndexByte(plusCodeAlphabet, upperASCII(code[0]))
+	if first*plusCodeBase >= int(maxLatitude-minLatitude) {
+		return false
+	}
+	if len(code) > 1 && code[1] != plusCodePadding {
+		second := strings.IndexByte(plusCodeAlphabet, upperASCII(code[1]))
+		if second*plusCodeBase >= int(maxLongitude-minLongitude) {
+			return false
+		}
+	}
+	return true
+}
+
+// isShortPlusCode reports whether code is a valid short Plus Code.
+func isShortPlusCode(code string) bool {
+	sep := strings.IndexByte(code, plusCodeSeparator)
+	return isValidPlusCode(code) && sep >= 0 && sep < plusCodeSeparatorPos
+}
+
+// upperASCII upper-cases an ASCII letter.
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
@@ -0,0 +1,297 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodePlusCode(t *testing.T) {
+	tests := []struct {
+		name       string
+		latitude   float64
+		longitude  float64
+		codeLength int
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "Latitude out of range",
+			latitude:   91,
+			codeLength: 10,
+			wantErr:    ErrLatitudeOutOfRange,
+		},
+		{
+			name:       "Longitude out of range",
+			longitude:  -181,
+			codeLength: 10,
+			wantErr:    ErrLongitudeOutOfRange,
+		},
+		{
+			name:       "Odd pair length",
+			codeLength: 7,
+			wantErr:    ErrInvalidCodeLength,
+		},
+		{
+			name:       "Too long",
+			codeLength: 16,
+			wantErr:    ErrInvalidCodeLength,
+		},
+		{
+			name:       "Padded code",
+			latitude:   20.375,
+			longitude:  2.775,
+			codeLength: 6,
+			want:       "7FG49Q00+",
+		},
+		{
+			name:       "Standard code",
+			latitude:   20.3700625,
+			longitude:  2.7821875,
+			codeLength: 10,
+			want:       "7FG49QCJ+2V",
+		},
+		{
+			name:       "Grid refinement",
+			latitude:   20.3701125,
+			longitude:  2.782234375,
+			codeLength: 11,
+			want:       "7FG49QCJ+2VX",
+		},
+		{
+			name:       "Southern hemisphere",
+			latitude:   -41.2730625,
+			longitude:  174.7859375,
+			codeLength: 10,
+			want:       "4VCPPQGP+Q9",
+		},
+		{
+			name:       "North pole",
+			latitude:   90,
+			longitude:  1,
+			codeLength: 4,
+			want:       "CFX30000+",
+		},
+		{
+			name:       "Antimeridian",
+			latitude:   1,
+			longitude:  180,
+			codeLength: 4,
+			want:       "62H20000+",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodePlusCode(tt.latitude, tt.longitude, tt.codeLength)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodePlusCode(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    BBox
+		wantErr error
+	}{
+		{code: "7FG49QCJ+2V", want: BBox{MinLatitude: 20.37, MaxLatitude: 20.370125, MinLongitude: 2.782125, MaxLongitude: 2.78225}},
+		{code: "7fg49q00+", want: BBox{MinLatitude: 20.35, MaxLatitude: 20.4, MinLongitude: 2.75, MaxLongitude: 2.8}},
+		{code: "CJ+2VX", wantErr: ErrInvalidPlusCode},
+		{code: "7FG49QCJ2V", wantErr: ErrInvalidPlusCode},
+		{code: "7FG49Q0J+", wantErr: ErrInvalidPlusCode},
+		{code: "7FG49QCJ+2", wantErr: ErrInvalidPlusCode},
+		{code: "7FG49QCA+2V", wantErr: ErrInvalidPlusCode},
+		{code: "XFG49QCJ+2V", wantErr: ErrInvalidPlusCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			lat, lng, bbox, err := DecodePlusCode(tt.code)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.InDelta(t, tt.want.MinLatitude, bbox.MinLatitude, tolerance)
+			assert.InDelta(t, tt.want.MaxLatitude, bbox.MaxLatitude, tolerance)
+			assert.InDelta(t, tt.want.MinLongitude, bbox.MinLongitude, tolerance)
+			assert.InDelta(t, tt.want.MaxLongitude, bbox.MaxLongitude, tolerance)
+			assert.InDelta(t, (tt.want.MinLatitude+tt.want.MaxLatitude)/2, lat, tolerance)
+			assert.InDelta(t, (tt.want.MinLongitude+tt.want.MaxLongitude)/2, lng, tolerance)
+		})
+	}
+}
+
+func TestShortenPlusCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		latitude  float64
+		longitude float64
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "Very close reference",
+			code:      "9C3W9QCJ+2VX",
+			latitude:  51.3701125,
+			longitude: -1.217765625,
+			want:      "+2VX",
+		},
+		{
+			name:      "Nearby reference",
+			code:      "9C3W9QCJ+2VX",
+			latitude:  51.3708675,
+			longitude: -1.217765625,
+			want:      "CJ+2VX",
+		},
+		{
+			name:      "Distant reference",
+			code:      "9C3W9QCJ+2VX",
+			latitude:  -10,
+			longitude: 100,
+			want:      "9C3W9QCJ+2VX",
+		},
+		{
+			name:    "Padded code",
+			code:    "9C3W0000+",
+			wantErr: ErrInvalidPlusCode,
+		},
+		{
+			name:    "Fewer than 6 digits",
+			code:    "9C000000+",
+			wantErr: ErrInvalidPlusCode,
+		},
+		{
+			name:     "Invalid reference",
+			code:     "9C3W9QCJ+2VX",
+			latitude: 100,
+			wantErr:  ErrLatitudeOutOfRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ShortenPlusCode(tt.code, tt.latitude, tt.longitude)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecoverPlusCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		latitude  float64
+		longitude float64
+		want      string
+		wantErr   error
+	}{
+		{
+			name: "Full code",
+			code: "9c3w9qcj+2vx",
+			want: "9C3W9QCJ+2VX",
+		},
+		{
+			name:      "Four digits removed",
+			code:      "9G8F+6W",
+			latitude:  47.4,
+			longitude: 8.6,
+			want:      "8FVC9G8F+6W",
+		},
+		{
+			name:      "Reference in the neighboring cell",
+			code:      "CJ+2VX",
+			latitude:  51.3708675,
+			longitude: -1.217765625,
+			want:      "9C3W9QCJ+2VX",
+		},
+		{
+			name:    "Invalid code",
+			code:    "CJ+2V+X",
+			wantErr: ErrInvalidPlusCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RecoverPlusCode(tt.code, tt.latitude, tt.longitude)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Shortening and recovering with the same reference round-trips.
+	for _, code := range []string{"9C3W9QCJ+2VX", "8FVC9G8F+6W", "4VCPPQGP+Q9"} {
+		lat, lng, _ := MustDecodePlusCode(code)
+		short, err := ShortenPlusCode(code, lat+0.001, lng-0.001)
+		require.NoError(t, err)
+		got, err := RecoverPlusCode(short, lat+0.001, lng-0.001)
+		require.NoError(t, err)
+		assert.Equal(t, code, got)
+	}
+}
+
+func TestPlusCodeCover(t *testing.T) {
+	got, err := PlusCodeCover("849VQHFJ+X6", Street)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"9q8yyk"}, got)
+
+	_, err = PlusCodeCover("HFJ+X6", Street)
+	assert.ErrorIs(t, err, ErrInvalidPlusCode)
+
+	_, err = PlusCodeCover("849VQHFJ+X6", 0)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+}
+
+func TestHashPlusCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		hash       string
+		codeLength int
+		want       []string
+		wantErr    error
+	}{
+		{
+			name:       "Invalid hash",
+			hash:       "9q8yy!",
+			codeLength: 6,
+			wantErr:    ErrInvalidHashFormat,
+		},
+		{
+			name:       "Invalid code length",
+			hash:       "9q8yy",
+			codeLength: 11,
+			wantErr:    ErrInvalidCodeLength,
+		},
+		{
+			name:       "Too many codes",
+			hash:       "9",
+			codeLength: 10,
+			wantErr:    ErrCoverTooLarge,
+		},
+		{
+			name:       "Cell within one code",
+			hash:       "9q8yy",
+			codeLength: 4,
+			want:       []string{"849V0000+"},
+		},
+		{
+			name:       "Cell spanning several codes",
+			hash:       "9q8yy",
+			codeLength: 6,
+			want:       []string{"849VPH00+", "849VPJ00+", "849VQH00+", "849VQJ00+"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HashPlusCodes(tt.hash, tt.codeLength)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
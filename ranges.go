@@ -0,0 +1,161 @@
+package geohash
+
+import (
+	"sort"
+)
+
+// uint64KeyBits is the number of significant bits of an integer GeoHash key (SubPoint precision).
+const uint64KeyBits = int(SubPoint) * bitsPerChar
+
+type (
+	// KeyRange is a half-open [Start, End) interval of string GeoHash keys in lexicographic order.
+	// An empty End means the range extends to the end of the key space.
+	KeyRange struct {
+		Start string
+		End   string
+	}
+
+	// IntRange is a half-open [Start, End) interval of integer GeoHash keys as produced by EncodeUint64.
+	IntRange struct {
+		Start uint64
+		End   uint64
+	}
+)
+
+// keySpan is a key range tracked both as integers and as the string keys delimiting it.
+type keySpan struct {
+	start, end       uint64
+	startKey, endKey string
+}
+
+// EncodeUint64 returns the integer GeoHash key for the given coordinates: the 60-bit SubPoint bitset.
+// Integer keys sort in the same order as the corresponding GeoHash strings.
+func EncodeUint64(latitude, longitude float64) (uint64, error) {
+	hash, err := Encode(latitude, longitude, SubPoint)
+	if err != nil {
+		return 0, err
+	}
+
+	bitset, _, _ := decodeFromBase32(hash)
+	return bitset, nil
+}
+
+// Ranges returns the string key ranges to scan for the cells at the given precision covering the bounding box.
+// When maxRanges is positive, the closest ranges are merged until at most maxRanges remain, trading
+// fewer scans for more false-positive area; otherwise the minimal exact set of ranges is returned.
+func Ranges(bbox BBox, precision Precision, maxRanges int) ([]KeyRange, error) {
+	cover, err := Cover(bbox, precision)
+	if err != nil {
+		return nil, err
+	}
+	return CoverRanges(cover, maxRanges)
+}
+
+// IntRanges returns the integer key ranges to scan for the cells at the given precision covering the bounding box.
+// The maxRanges knob behaves as in Ranges.
+func IntRanges(bbox BBox, precision Precision, maxRanges int) ([]IntRange, error) {
+	cover, err := Cover(bbox, precision)
+	if err != nil {
+		return nil, err
+	}
+	return CoverIntRanges(cover, maxRanges)
+}
+
+// CoverRanges returns the string key ranges to scan for a covering of GeoHash cells of any precision.
+// The maxRanges knob behaves as in Ranges. Returns an error if any hash is invalid.
+func CoverRanges(cover []string, maxRanges int) ([]KeyRange, error) {
+	spans, err := coverSpans(cover, maxRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]KeyRange, len(spans))
+	for i, s := range spans {
+		ranges[i] = KeyRange{Start: s.startKey, End: s.endKey}
+	}
+	return ranges, nil
+}
+
+// CoverIntRanges returns the integer key ranges to scan for a covering of GeoHash cells of any precision.
+// The maxRanges knob behaves as in Ranges. Returns an error if any hash is invalid.
+func CoverIntRanges(cover []string, maxRanges int) ([]IntRange, error) {
+	spans, err := coverSpans(cover, maxRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]IntRange, len(spans))
+	for i, s := range spans {
+		ranges[i] = IntRange{Start: s.start, End: s.end}
+	}
+	return ranges, nil
+}
+
+// coverSpans converts a covering into sorted, merged key spans, reduced to at most maxRanges when positive.
+func coverSpans(cover []string, maxRanges int) ([]keySpan, error) {
+	hashes, err := Compact(cover)
+	if err != nil {
+		return nil, err
+	}
+
+	var spans []keySpan
+	for _, hash := range hashes {
+		bitset, precision, _ := decodeFromBase32(hash)
+		shift := uint64KeyBits - int(precision)*bitsPerChar
+		s := keySpan{
+			start:    bitset << shift,
+			end:      (bitset + 1) << shift,
+			startKey: hash,
+			endKey:   successor(hash),
+		}
+
+		if n := len(spans); n > 0 && spans[n-1].end == s.start {
+			spans[n-1].end, spans[n-1].endKey = s.end, s.endKey
+			continue
+		}
+		spans = append(spans, s)
+	}
+
+	if maxRanges <= 0 || len(spans) <= maxRanges {
+		return spans, nil
+	}
+
+	// Merging two neighbors never changes the other gaps, so keeping the widest maxRanges-1 gaps
+	// and closing every other one minimizes the total false-positive key space.
+	gaps := make([]int, len(spans)-1)
+	for i := range gaps {
+		gaps[i] = i
+	}
+	sort.SliceStable(gaps, func(a, b int) bool {
+		return spans[gaps[a]+1].start-spans[gaps[a]].end > spans[gaps[b]+1].start-spans[gaps[b]].end
+	})
+	keep := make([]bool, len(spans)-1)
+	for _, i := range gaps[:maxRanges-1] {
+		keep[i] = true
+	}
+
+	merged := spans[:1]
+	for i := 1; i < len(spans); i++ {
+		if keep[i-1] {
+			merged = append(merged, spans[i])
+			continue
+		}
+		last := &merged[len(merged)-1]
+		last.end, last.endKey = spans[i].end, spans[i].endKey
+	}
+	return merged, nil
+}
+
+// successor returns the smallest string greater than every string prefixed by hash,
+// or an empty string if no such GeoHash exists.
+func successor(hash string) string {
+	buf := []byte(hash)
+	for i := len(buf) - 1; i >= 0; i-- {
+		index, _ := StdEncoding.value(rune(buf[i]))
+		if index < uint64(len(StdEncoding.alphabet)-1) {
+			buf[i] = StdEncoding.alphabet[index+1]
+			return string(buf[:i+1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeUint64(t *testing.T) {
+	_, err := EncodeUint64(91, 0)
+	assert.ErrorIs(t, err, ErrLatitudeOutOfRange)
+
+	got, err := EncodeUint64(-90, -180)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), got)
+
+	got, err = EncodeUint64(90, 180)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1)<<60-1, got)
+
+	a, _ := EncodeUint64(37.7749, -122.4194)
+	b, _ := EncodeUint64(40.7128, -74.0060)
+	assert.Less(t, a, b, "integer keys must sort like GeoHash strings")
+}
+
+func TestCoverRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		cover     []string
+		maxRanges int
+		want      []KeyRange
+		wantErr   error
+	}{
+		{
+			name:    "Invalid hash",
+			cover:   []string{"9q8yy!"},
+			wantErr: ErrInvalidHashFormat,
+		},
+		{
+			name:  "Empty cover",
+			cover: nil,
+			want:  []KeyRange{},
+		},
+		{
+			name:  "Adjacent cells merged",
+			cover: []string{"9q8y", "9q8z", "9q9"},
+			want:  []KeyRange{{Start: "9q8y", End: "9qb"}},
+		},
+		{
+			name:  "Carry on last character",
+			cover: []string{"9qz"},
+			want:  []KeyRange{{Start: "9qz", End: "9r"}},
+		},
+		{
+			name:  "End of key space",
+			cover: []string{"zz"},
+			want:  []KeyRange{{Start: "zz", End: ""}},
+		},
+		{
+			name:  "Disjoint cells without limit",
+			cover: []string{"9q8", "9q9b", "dr5"},
+			want: []KeyRange{
+				{Start: "9q8", End: "9q9"},
+				{Start: "9q9b", End: "9q9c"},
+				{Start: "dr5", End: "dr6"},
+			},
+		},
+		{
+			name:      "Disjoint cells merged across smallest gap",
+			cover:     []string{"9q8", "9q9b", "dr5"},
+			maxRanges: 2,
+			want: []KeyRange{
+				{Start: "9q8", End: "9q9c"},
+				{Start: "dr5", End: "dr6"},
+			},
+		},
+		{
+			name:      "Single range",
+			cover:     []string{"9q8", "9q9b", "dr5"},
+			maxRanges: 1,
+			want:      []KeyRange{{Start: "9q8", End: "dr6"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CoverRanges(tt.cover, tt.maxRanges)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCoverIntRanges(t *testing.T) {
+	got, err := CoverIntRanges([]string{"0", "zz"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []IntRange{
+		{Start: 0, End: 1 << 55},
+		{Start: 1<<60 - 1<<50, End: 1 << 60},
+	}, got)
+
+	key, _ := EncodeUint64(37.7749, -122.4194)
+	got, err = CoverIntRanges([]string{"9q8yy"}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.True(t, key >= got[0].Start && key < got[0].End)
+}
+
+func TestRanges(t *testing.T) {
+	bbox := BBox{MinLatitude: 37.70, MaxLatitude: 37.85, MinLongitude: -122.52, MaxLongitude: -122.35}
+
+	_, err := Ranges(BBox{MinLatitude: 1}, City, 0)
+	assert.ErrorIs(t, err, ErrInvalidBBox)
+
+	exact, err := Ranges(bbox, City, 0)
+	assert.NoError(t, err)
+
+	limited, err := Ranges(bbox, City, 3)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(limited), 3)
+	assert.LessOrEqual(t, len(limited), len(exact))
+
+	for _, hash := range MustCover(bbox, City) {
+		assert.True(t, keyRangesContain(limited, hash), "hash %s not covered", hash)
+	}
+}
+
+func TestIntRanges(t *testing.T) {
+	bbox := BBox{MinLatitude: 37.70, MaxLatitude: 37.85, MinLongitude: -122.52, MaxLongitude: -122.35}
+
+	_, err := IntRanges(bbox, 0, 0)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	got, err := IntRanges(bbox, City, 2)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(got), 2)
+
+	key, _ := EncodeUint64(37.7749, -122.4194)
+	found := false
+	for _, r := range got {
+		if key >= r.Start && key < r.End {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func keyRangesContain(ranges []KeyRange, hash string) bool {
+	for _, r := range ranges {
+		if hash >= r.Start && (r.End == "" || hash < r.End) {
+			return true
+		}
+	}
+	return false
+}
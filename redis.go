@@ -0,0 +1,345 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// RedisMinLatitude is the lowest latitude accepted by Redis GEOADD (Web Mercator limit).
+	RedisMinLatitude float64 = -85.05112878
+
+	// RedisMaxLatitude is the highest latitude accepted by Redis GEOADD (Web Mercator limit).
+	RedisMaxLatitude float64 = 85.05112878
+
+	// redisStep is the number of bits per coordinate used by Redis (GEO_STEP_MAX).
+	redisStep = 26
+
+	// redisBits is the total number of interleaved bits in a Redis GEO score.
+	redisBits = 2 * redisStep
+
+	// redisHashLength is the length of the string returned by the Redis GEOHASH command.
+	redisHashLength = 11
+
+	// redisEarthRadius is the Earth radius in meters used by Redis for distance computations.
+	redisEarthRadius = 6372797.560856
+
+	// redisMercatorMax is the Web Mercator half-extent in meters used by Redis to estimate search steps.
+	redisMercatorMax = 20037726.37
+)
+
+var (
+	// ErrInvalidRedisScore is returned when a number is not a score Redis GEOADD can store.
+	ErrInvalidRedisScore = errors.New("invalid redis score")
+
+	// ErrInvalidRadius is returned when a search radius or box size is negative or not a finite number.
+	ErrInvalidRadius = errors.New("invalid radius")
+)
+
+// RedisRange is a half-open [Min, Max) interval of Redis GEO scores, as scanned by ZRANGEBYSCORE.
+type RedisRange struct {
+	Min uint64
+	Max uint64
+}
+
+// redisArea is a Redis cell expressed as its interleaved bits and step (bits per coordinate).
+type redisArea struct {
+	bits uint64
+	step uint
+}
+
+// EncodeRedis returns the 52-bit score Redis GEOADD stores for the given latitude and longitude.
+// Like Redis, the upper bounds of the ranges (latitude 85.05112878 and longitude 180) overflow into the next
+// bit instead of falling in the last cell, so their scores take bit 52 or 53.
+// Returns an error if the latitude is outside the Redis range (±85.05112878) or the longitude is out of range.
+func EncodeRedis(latitude, longitude float64) (uint64, error) {
+	if err := checkLatitude(latitude, RedisMinLatitude, RedisMaxLatitude); err != nil {
+		return 0, err
+	}
+	if err := checkLongitude(longitude); err != nil {
+		return 0, err
+	}
+
+	return redisEncode(latitude, longitude, RedisMinLatitude, RedisMaxLatitude, redisStep), nil
+}
+
+// MustEncodeRedis returns the Redis GEO score for the given coordinates or panics if an error occurs.
+func MustEncodeRedis(latitude, longitude float64) uint64 {
+	score, err := EncodeRedis(latitude, longitude)
+	if err != nil {
+		panic(err)
+	}
+	return score
+}
+
+// DecodeRedis decodes a Redis GEO score into the latitude and longitude Redis GEOPOS would return.
+// Returns an error if the score is neither 52 bits wide nor the score of an upper bound.
+func DecodeRedis(score uint64) (latitude, longitude float64, err error) {
+	if !validRedisScore(score) {
+		return 0, 0, ErrInvalidRedisScore
+	}
+
+	latitude, longitude = redisDecodeCenter(score)
+	return latitude, longitude, nil
+}
+
+// MustDecodeRedis decodes a Redis GEO score into latitude and longitude or panics if an error occurs.
+func MustDecodeRedis(score uint64) (latitude, longitude float64) {
+	lat, lon, err := DecodeRedis(score)
+	if err != nil {
+		panic(err)
+	}
+	return lat, lon
+}
+
+// RedisHash returns the 11-character GeoHash string the Redis GEOHASH command reports for a score.
+// The score is decoded and re-encoded against the standard latitude range, and the last character
+// is always '0' because a Redis score only carries 52 bits.
+func RedisHash(score uint64) (string, error) {
+	latitude, longitude, err := DecodeRedis(score)
+	if err != nil {
+		return "", err
+	}
+
+	bits := redisEncode(latitude, longitude, minLatitude, maxLatitude, redisStep)
+
+	var buf [redisHashLength]byte
+	for i := 0; i < redisHashLength-1; i++ {
+		buf[i] = alphabet[(bits>>(redisBits-(i+1)*bitsPerChar))&0x1F]
+	}
+	buf[redisHashLength-1] = alphabet[0]
+
+	return string(buf[:]), nil
+}
+
+// RedisRadiusRanges returns the score ranges Redis GEOSEARCH BYRADIUS scans for a circle of the given
+// radius in meters around the given coordinates, in the order Redis visits them.
+// Returns an error if the coordinates are out of range or the radius is invalid.
+func RedisRadiusRanges(latitude, longitude, radius float64) ([]RedisRange, error) {
+	if radius < 0 || math.IsNaN(radius) || math.IsInf(radius, 0) {
+		return nil, ErrInvalidRadius
+	}
+	return redisSearchRanges(latitude, longitude, radius, radius, radius)
+}
+
+// RedisBoxRanges returns the score ranges Redis GEOSEARCH BYBOX scans for a box of the given width and
+// height in meters centered on the given coordinates, in the order Redis visits them.
+// Returns an error if the coordinates are out of range or the box size is invalid.
+func RedisBoxRanges(latitude, longitude, width, height float64) ([]RedisRange, error) {
+	for _, v := range []float64{width, height} {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, ErrInvalidRadius
+		}
+	}
+	return redisSearchRanges(latitude, longitude, width/2, height/2, math.Hypot(width/2, height/2))
+}
+
+// redisSearchRanges mirrors geohashCalculateAreasByShapeWGS84 and membersOfAllNeighbors from Redis.
+func redisSearchRanges(latitude, longitude, halfWidth, halfHeight, radius float64) ([]RedisRange, error) {
+	if err := checkLatitude(latitude, RedisMinLatitude, RedisMaxLatitude); err != nil {
+		return nil, err
+	}
+	if err := checkLongitude(longitude); err != nil {
+		return nil, err
+	}
+
+	latDelta := radToDeg(halfHeight / redisEarthRadius)
+	lngDeltaTop := radToDeg(halfWidth / redisEarthRadius / math.Cos(degToRad(latitude+latDelta)))
+	lngDeltaBottom := radToDeg(halfWidth / redisEarthRadius / math.Cos(degToRad(latitude-latDelta)))
+
+	minLat, maxLat := latitude-latDelta, latitude+latDelta
+	minLng, maxLng := longitude-lngDeltaTop, longitude+lngDeltaTop
+	if latitude < 0 {
+		minLng, maxLng = longitude-lngDeltaBottom, longitude+lngDeltaBottom
+	}
+
+	step := redisEstimateSteps(radius, latitude)
+	center := redisArea{bits: redisEncode(latitude, longitude, RedisMinLatitude, RedisMaxLatitude, step), step: step}
+	neighbors := center.neighbors()
+
+	north := neighbors[1].bounds()
+	south := neighbors[2].bounds()
+	east := neighbors[3].bounds()
+	west := neighbors[4].bounds()
+	if step > 1 && (north.MaxLatitude < maxLat || south.MinLatitude > minLat ||
+		east.MaxLongitude < maxLng || west.MinLongitude > minLng) {
+		step--
+		center = redisArea{bits: redisEncode(latitude, longitude, RedisMinLatitude, RedisMaxLatitude, step), step: step}
+		neighbors = center.neighbors()
+	}
+
+	// Neighbor order: center, N, S, E, W, NE, NW, SE, SW.
+	skip := [9]bool{}
+	if step >= 2 {
+		area := center.bounds()
+		if area.MinLatitude < minLat {
+			skip[2], skip[8], skip[7] = true, true, true
+		}
+		if area.MaxLatitude > maxLat {
+			skip[1], skip[5], skip[6] = true, true, true
+		}
+		if area.MinLongitude < minLng {
+			skip[4], skip[8], skip[6] = true, true, true
+		}
+		if area.MaxLongitude > maxLng {
+			skip[3], skip[7], skip[5] = true, true, true
+		}
+	}
+
+	ranges := make([]RedisRange, 0, len(neighbors))
+	last := -1
+	for i, n := range neighbors {
+		if skip[i] {
+			continue
+		}
+		if last > 0 && neighbors[last] == n {
+			continue
+		}
+		last = i
+
+		shift := redisBits - 2*n.step
+		ranges = append(ranges, RedisRange{Min: n.bits << shift, Max: (n.bits + 1) << shift})
+	}
+
+	return ranges, nil
+}
+
+// redisEstimateSteps mirrors geohashEstimateStepsByRadius from Redis.
+func redisEstimateSteps(radius, latitude float64) uint {
+	if radius == 0 {
+		return redisStep
+	}
+
+	step := 1
+	for radius < redisMercatorMax {
+		radius *= 2
+		step++
+	}
+	step -= 2
+
+	if latitude > 66 || latitude < -66 {
+		step--
+		if latitude > 80 || latitude < -80 {
+			step--
+		}
+	}
+
+	if step < 1 {
+		step = 1
+	}
+	if step > redisStep {
+		step = redisStep
+	}
+	return uint(step)
+}
+
+// neighbors returns the area itself followed by its eight neighbors in Redis order: N, S, E, W, NE, NW, SE, SW.
+func (a redisArea) neighbors() [9]redisArea {
+	return [9]redisArea{
+		a,
+		a.move(0, 1),
+		a.move(0, -1),
+		a.move(1, 0),
+		a.move(-1, 0),
+		a.move(1, 1),
+		a.move(-1, 1),
+		a.move(1, -1),
+		a.move(-1, -1),
+	}
+}
+
+// move shifts the area by dx cells along longitude and dy cells along latitude, wrapping around the grid.
+func (a redisArea) move(dx, dy int) redisArea {
+	mask := uint64(1)<<a.step - 1
+	lat, lng := deinterleave(a.bits)
+	lat = uint64(int64(lat)+int64(dy)) & mask
+	lng = uint64(int64(lng)+int64(dx)) & mask
+	return redisArea{bits: interleave(lat, lng), step: a.step}
+}
+
+// bounds returns the area of the cell against the Redis latitude range.
+func (a redisArea) bounds() BBox {
+	lat, lng := deinterleave(a.bits)
+	cells := float64(uint64(1) << a.step)
+	latScale := RedisMaxLatitude - RedisMinLatitude
+	lngScale := maxLongitude - minLongitude
+
+	return BBox{
+		MinLatitude:  RedisMinLatitude + float64(lat)/cells*latScale,
+		MaxLatitude:  RedisMinLatitude + float64(lat+1)/cells*latScale,
+		MinLongitude: minLongitude + float64(lng)/cells*lngScale,
+		MaxLongitude: minLongitude + float64(lng+1)/cells*lngScale,
+	}
+}
+
+// redisEncode mirrors geohashEncode from Redis for the given latitude bounds and step.
+func redisEncode(latitude, longitude, latMin, latMax float64, step uint) uint64 {
+	cells := float64(uint64(1) << step)
+	latOffset := (latitude - latMin) / (latMax - latMin) * cells
+	lngOffset := (longitude - minLongitude) / (maxLongitude - minLongitude) * cells
+
+	// The upper bound overflows into the next bit, as in Redis.
+	return interleave(uint64(latOffset), uint64(lngOffset))
+}
+
+// validRedisScore reports whether a score is one EncodeRedis returns: every 52-bit score, plus those whose
+// latitude or longitude index overflows to exactly 2^26 at the upper bound of its range.
+func validRedisScore(score uint64) bool {
+	lat, lng := deinterleave(score)
+	return score < 1<<(redisBits+2) && lat <= 1<<redisStep && lng <= 1<<redisStep
+}
+
+// redisDecodeCenter mirrors geohashDecodeToLongLatWGS84 from Redis for a full-precision score.
+func redisDecodeCenter(score uint64) (latitude, longitude float64) {
+	area := redisArea{bits: score, step: redisStep}.bounds()
+
+	latitude = (area.MinLatitude + area.MaxLatitude) / 2
+	latitude = math.Max(RedisMinLatitude, math.Min(RedisMaxLatitude, latitude))
+
+	longitude = (area.MinLongitude + area.MaxLongitude) / 2
+	longitude = math.Max(minLongitude, math.Min(maxLongitude, longitude))
+
+	return latitude, longitude
+}
+
+// interleave spreads the low 32 bits of lat into even positions and of lng into odd positions.
+func interleave(lat, lng uint64) uint64 {
+	return spreadBits(lat) | spreadBits(lng)<<1
+}
+
+// deinterleave is the inverse of interleave.
+func deinterleave(bits uint64) (lat, lng uint64) {
+	return squashBits(bits), squashBits(bits >> 1)
+}
+
+// spreadBits moves bit i of the low 32 bits of x to bit 2i.
+func spreadBits(x uint64) uint64 {
+	x &= 0x00000000FFFFFFFF
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// squashBits moves bit 2i of x to bit i, dropping odd bits.
+func squashBits(x uint64) uint64 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+	return x
+}
+
+// degToRad converts degrees to radians.
+func degToRad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// radToDeg converts radians to degrees.
+func radToDeg(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,272 @@
+package geohash
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeRedis(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		want      uint64
+		wantErr   error
+	}{
+		{
+			name:      "Latitude out of range - beyond mercator limit",
+			latitude:  85.1,
+			longitude: 0,
+			wantErr:   ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Longitude out of range - too high",
+			latitude:  0,
+			longitude: 180.1,
+			wantErr:   ErrLongitudeOutOfRange,
+		},
+		{
+			name:      "Palermo",
+			latitude:  38.115556,
+			longitude: 13.361389,
+			want:      3479099956230698,
+		},
+		{
+			name:      "Catania",
+			latitude:  37.502669,
+			longitude: 15.087269,
+			want:      3479447370796909,
+		},
+		{
+			name:      "Edge case - min latitude and longitude",
+			latitude:  RedisMinLatitude,
+			longitude: -180,
+			want:      0,
+		},
+		{
+			name:      "Edge case - max latitude overflows into bit 52",
+			latitude:  RedisMaxLatitude,
+			longitude: -180,
+			want:      1 << 52,
+		},
+		{
+			name:      "Edge case - max longitude overflows into bit 53",
+			latitude:  RedisMinLatitude,
+			longitude: 180,
+			want:      1 << 53,
+		},
+		{
+			name:      "Edge case - max latitude and longitude",
+			latitude:  RedisMaxLatitude,
+			longitude: 180,
+			want:      1<<52 | 1<<53,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeRedis(tt.latitude, tt.longitude)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMustEncodeRedis(t *testing.T) {
+	assert.Panics(t, func() { MustEncodeRedis(90, 0) })
+	assert.Equal(t, uint64(3479099956230698), MustEncodeRedis(38.115556, 13.361389))
+}
+
+func TestDecodeRedis(t *testing.T) {
+	tests := []struct {
+		name          string
+		score         uint64
+		wantLatitude  float64
+		wantLongitude float64
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "Invalid score - wider than 54 bits",
+			score:   1 << 54,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Invalid score - overflow bit with a non-zero index",
+			score:   1<<52 | 1,
+			wantErr: assert.Error,
+		},
+		{
+			name:          "Edge case - max latitude and longitude",
+			score:         1<<52 | 1<<53,
+			wantLatitude:  RedisMaxLatitude,
+			wantLongitude: 180,
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "Palermo",
+			score:         3479099956230698,
+			wantLatitude:  38.11555639549629859,
+			wantLongitude: 13.36138933897018433,
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "Catania",
+			score:         3479447370796909,
+			wantLatitude:  37.50266842333162032,
+			wantLongitude: 15.08726745843887329,
+			wantErr:       assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotLatitude, gotLongitude, err := DecodeRedis(tt.score)
+			if !tt.wantErr(t, err, fmt.Sprintf("DecodeRedis(%v)", tt.score)) {
+				return
+			}
+			assert.InDeltaf(t, tt.wantLatitude, gotLatitude, tolerance, "DecodeRedis(%v)", tt.score)
+			assert.InDeltaf(t, tt.wantLongitude, gotLongitude, tolerance, "DecodeRedis(%v)", tt.score)
+		})
+	}
+}
+
+func TestMustDecodeRedis(t *testing.T) {
+	assert.Panics(t, func() { MustDecodeRedis(1 << 60) })
+	lat, lon := MustDecodeRedis(3479099956230698)
+	assert.InDelta(t, 38.115556, lat, 1e-5)
+	assert.InDelta(t, 13.361389, lon, 1e-5)
+}
+
+func TestRedisHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		score   uint64
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "Invalid score - wider than 54 bits",
+			score:   1 << 54,
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Edge case - max latitude and longitude",
+			score:   1<<52 | 1<<53,
+			want:    "bp05b5048p0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Palermo",
+			score:   3479099956230698,
+			want:    "sqc8b49rny0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Catania",
+			score:   3479447370796909,
+			want:    "sqdtr74hyu0",
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RedisHash(tt.score)
+			if !tt.wantErr(t, err, fmt.Sprintf("RedisHash(%v)", tt.score)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "RedisHash(%v)", tt.score)
+		})
+	}
+}
+
+func TestRedisRadiusRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		radius    float64
+		wantErr   error
+	}{
+		{
+			name:      "Invalid radius - negative",
+			latitude:  37,
+			longitude: 15,
+			radius:    -1,
+			wantErr:   ErrInvalidRadius,
+		},
+		{
+			name:      "Latitude out of range",
+			latitude:  89,
+			longitude: 15,
+			radius:    1000,
+			wantErr:   ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Sicily - 200 km",
+			latitude:  37,
+			longitude: 15,
+			radius:    200000,
+		},
+		{
+			name:      "Palermo - 1 km",
+			latitude:  38.115556,
+			longitude: 13.361389,
+			radius:    1000,
+		},
+		{
+			name:      "Zero radius",
+			latitude:  38.115556,
+			longitude: 13.361389,
+			radius:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RedisRadiusRanges(tt.latitude, tt.longitude, tt.radius)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			score := MustEncodeRedis(tt.latitude, tt.longitude)
+			found := false
+			for _, r := range got {
+				assert.Less(t, r.Min, r.Max)
+				if score >= r.Min && score < r.Max {
+					found = true
+				}
+			}
+			assert.True(t, found, "center score %d not covered by %v", score, got)
+			assert.LessOrEqual(t, len(got), 9)
+		})
+	}
+}
+
+func TestRedisRadiusRangesCoversMembers(t *testing.T) {
+	// Catania is ~166 km from Palermo, so a 200 km search must scan its score.
+	got, err := RedisRadiusRanges(38.115556, 13.361389, 200000)
+	assert.NoError(t, err)
+
+	score := MustEncodeRedis(37.502669, 15.087269)
+	found := false
+	for _, r := range got {
+		if score >= r.Min && score < r.Max {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestRedisBoxRanges(t *testing.T) {
+	_, err := RedisBoxRanges(37, 15, -1, 10)
+	assert.ErrorIs(t, err, ErrInvalidRadius)
+
+	got, err := RedisBoxRanges(37, 15, 400000, 400000)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, got)
+}
@@ -0,0 +1,92 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ErrEmptyCover is returned when sampling points from a covering without cells.
+var ErrEmptyCover = errors.New("empty cover")
+
+// RandomPoint returns a point drawn uniformly over the spherical area of the cell identified by hash, so
+// that points do not crowd towards the pole side of the cell. Random numbers are drawn from rng, or from
+// the global math/rand source when rng is nil.
+// Returns an error if the hash is invalid.
+func RandomPoint(hash string, rng *rand.Rand) (latitude, longitude float64, err error) {
+	_, _, bbox, err := DecodeBBox(hash)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	latitude, longitude = randomPoint(bbox, rng)
+	return latitude, longitude, nil
+}
+
+// RandomPoints returns n points drawn uniformly over the area of a covering: each point falls in a cell
+// chosen with probability proportional to the cell area, then uniformly within it. Cells are expected not
+// to overlap, as returned by Cover or Compact. A non-positive n returns no points.
+// Returns an error if any hash is invalid or the covering is empty.
+func RandomPoints(cover []string, n int, rng *rand.Rand) ([]LatLng, error) {
+	if len(cover) == 0 {
+		return nil, ErrEmptyCover
+	}
+
+	cells := make([]BBox, len(cover))
+	cumulative := make([]float64, len(cover))
+	total := 0.0
+	for i, hash := range cover {
+		_, _, bbox, err := DecodeBBox(hash)
+		if err != nil {
+			return nil, err
+		}
+		cells[i] = bbox
+		total += bbox.Area()
+		cumulative[i] = total
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	random := rand.Float64
+	if rng != nil {
+		random = rng.Float64
+	}
+
+	points := make([]LatLng, n)
+	for i := range points {
+		cell := sort.SearchFloat64s(cumulative, random()*total)
+		cell = min(cell, len(cells)-1)
+		points[i].Latitude, points[i].Longitude = randomPoint(cells[cell], rng)
+	}
+	return points, nil
+}
+
+// Area returns the area of the bounding box on the sphere in square meters.
+// A box whose MinLongitude is greater than its MaxLongitude is treated as crossing the antimeridian.
+func (b BBox) Area() float64 {
+	width := b.MaxLongitude - b.MinLongitude
+	if width < 0 {
+		width += maxLongitude - minLongitude
+	}
+	height := math.Sin(degToRad(b.MaxLatitude)) - math.Sin(degToRad(b.MinLatitude))
+	return earthRadius * earthRadius * degToRad(width) * height
+}
+
+// randomPoint draws a point uniformly over the spherical area of the bounding box: the longitude is
+// uniform, while the latitude is uniform in its sine, as the area of a band grows with sin(latitude).
+// Points stay below the upper edges, which belong to the next cells.
+func randomPoint(bbox BBox, rng *rand.Rand) (latitude, longitude float64) {
+	random := rand.Float64
+	if rng != nil {
+		random = rng.Float64
+	}
+
+	lo := math.Sin(degToRad(bbox.MinLatitude))
+	hi := math.Sin(degToRad(bbox.MaxLatitude))
+	latitude = radToDeg(math.Asin(lo + (hi-lo)*random()))
+	latitude = math.Max(bbox.MinLatitude, math.Min(math.Nextafter(bbox.MaxLatitude, bbox.MinLatitude), latitude))
+	longitude = bbox.MinLongitude + (bbox.MaxLongitude-bbox.MinLongitude)*random()
+	return latitude, longitude
+}
@@ -0,0 +1,140 @@
+package geohash
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		wantErr error
+	}{
+		{name: "Global cell", hash: "b"},
+		{name: "Polar cell", hash: "zz"},
+		{name: "Street cell", hash: "9q8yy"},
+		{name: "Wide cell", hash: "9q8yyk8ytpxrs9q8"},
+		{name: "Invalid hash", hash: "9q8ya", wantErr: ErrInvalidHashFormat},
+		{name: "Empty hash", hash: "", wantErr: ErrInvalidHashLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 100; i++ {
+				lat, lng, err := RandomPoint(tt.hash, rng)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.hash, MustEncode(lat, lng, Precision(len(tt.hash))))
+			}
+		})
+	}
+}
+
+func TestRandomPointUniformArea(t *testing.T) {
+	// The cell spans latitudes 45 to 90, where the lower half of the degrees holds most of the area.
+	const samples = 20000
+	rng := rand.New(rand.NewSource(5))
+
+	below := 0
+	for i := 0; i < samples; i++ {
+		lat, _, err := RandomPoint("u", rng)
+		require.NoError(t, err)
+		if lat < 67.5 {
+			below++
+		}
+	}
+
+	want := (math.Sin(degToRad(67.5)) - math.Sin(degToRad(45))) / (1 - math.Sin(degToRad(45)))
+	assert.InDelta(t, want, float64(below)/samples, 0.01)
+}
+
+func TestRandomPoints(t *testing.T) {
+	cover := []string{"b", "s"}
+	const samples = 20000
+
+	points, err := RandomPoints(cover, samples, rand.New(rand.NewSource(9)))
+	require.NoError(t, err)
+	require.Len(t, points, samples)
+
+	counts := make(map[string]int)
+	for _, p := range points {
+		hash := MustEncode(p.Latitude, p.Longitude, Global)
+		require.Contains(t, cover, hash)
+		counts[hash]++
+	}
+
+	// Cell b spans latitudes 45 to 90 and s 0 to 45, so s holds sqrt(2)+1 times the area of b.
+	_, _, polar := MustDecodeBBox("b")
+	_, _, equatorial := MustDecodeBBox("s")
+	want := polar.Area() / (polar.Area() + equatorial.Area())
+	assert.InDelta(t, want, float64(counts["b"])/samples, 0.01)
+
+	points, err = RandomPoints(cover, 0, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, points)
+
+	_, err = RandomPoints(nil, 10, nil)
+	assert.ErrorIs(t, err, ErrEmptyCover)
+
+	_, err = RandomPoints([]string{"b", "a"}, 10, nil)
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+}
+
+func TestRandomPointsSeeded(t *testing.T) {
+	cover := MustCover(BBox{MinLatitude: 37.7, MaxLatitude: 37.8, MinLongitude: -122.5, MaxLongitude: -122.4}, City)
+
+	a, err := RandomPoints(cover, 50, rand.New(rand.NewSource(11)))
+	require.NoError(t, err)
+	b, err := RandomPoints(cover, 50, rand.New(rand.NewSource(11)))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	for _, p := range a {
+		hash := MustEncode(p.Latitude, p.Longitude, City)
+		assert.Contains(t, cover, hash)
+	}
+}
+
+func TestBBoxArea(t *testing.T) {
+	tests := []struct {
+		name string
+		bbox BBox
+		want float64
+	}{
+		{
+			name: "Whole sphere",
+			bbox: BBox{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+			want: 4 * math.Pi * earthRadius * earthRadius,
+		},
+		{
+			name: "Northern hemisphere",
+			bbox: BBox{MinLatitude: 0, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180},
+			want: 2 * math.Pi * earthRadius * earthRadius,
+		},
+		{
+			name: "Across the antimeridian",
+			bbox: BBox{MinLatitude: 0, MaxLatitude: 90, MinLongitude: 90, MaxLongitude: -90},
+			want: math.Pi * earthRadius * earthRadius,
+		},
+		{
+			name: "Degenerate",
+			bbox: BBox{MinLatitude: 10, MaxLatitude: 10, MinLongitude: 0, MaxLongitude: 1},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.bbox.Area(), tt.want*1e-12)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import "github.com/aoliveti/geohash"
+
+// geoJSON is implemented by the GeoJSON response bodies, served as application/geo+json.
+type geoJSON interface {
+	geoJSON()
+}
+
+type (
+	// feature is a GeoJSON Feature.
+	feature struct {
+		Type       string         `json:"type"`
+		Geometry   geometry       `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	}
+
+	// featureCollection is a GeoJSON FeatureCollection.
+	featureCollection struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}
+
+	// geometry is a GeoJSON Point or Polygon.
+	geometry struct {
+		Type        string `json:"type"`
+		Coordinates any    `json:"coordinates"`
+	}
+)
+
+func (feature) geoJSON()           {}
+func (featureCollection) geoJSON() {}
+
+// pointFeature returns a Point feature; GeoJSON positions are longitude first.
+func pointFeature(lat, lng float64, properties map[string]any) feature {
+	return feature{
+		Type:       "Feature",
+		Geometry:   geometry{Type: "Point", Coordinates: []float64{lng, lat}},
+		Properties: properties,
+	}
+}
+
+// cellFeature returns the Polygon feature of a cell, with its hash as a property.
+func cellFeature(hash string, bbox geohash.BBox) feature {
+	ring := [][]float64{
+		{bbox.MinLongitude, bbox.MinLatitude},
+		{bbox.MaxLongitude, bbox.MinLatitude},
+		{bbox.MaxLongitude, bbox.MaxLatitude},
+		{bbox.MinLongitude, bbox.MaxLatitude},
+		{bbox.MinLongitude, bbox.MinLatitude},
+	}
+	return feature{
+		Type:       "Feature",
+		Geometry:   geometry{Type: "Polygon", Coordinates: [][][]float64{ring}},
+		Properties: map[string]any{"hash": hash},
+	}
+}
+
+// cellCollection returns the Polygon features of valid cells.
+func cellCollection(hashes []string) featureCollection {
+	fc := featureCollection{Type: "FeatureCollection", Features: make([]feature, 0, len(hashes))}
+	for _, hash := range hashes {
+		_, _, bbox := geohash.MustDecodeBBox(hash)
+		fc.Features = append(fc.Features, cellFeature(hash, bbox))
+	}
+	return fc
+}
@@ -0,0 +1,347 @@
+// Package server exposes the geohash package over HTTP with JSON and GeoJSON responses.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aoliveti/geohash"
+)
+
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeGeoJSON = "application/geo+json"
+)
+
+// DefaultMaxCoverCells is the default limit of cells returned by the /cover endpoint.
+const DefaultMaxCoverCells = 10000
+
+// clientErrors are the package errors caused by invalid input, answered with 400 Bad Request.
+var clientErrors = []error{
+	geohash.ErrLatitudeOutOfRange,
+	geohash.ErrLongitudeOutOfRange,
+	geohash.ErrPrecisionOutOfRange,
+	geohash.ErrInvalidHashLength,
+	geohash.ErrInvalidHashFormat,
+	geohash.ErrInvalidCoordinate,
+	geohash.ErrInvalidBBox,
+	geohash.ErrCoverTooLarge,
+	errInvalidParameter,
+}
+
+// errInvalidParameter is returned when a query parameter is missing or malformed.
+var errInvalidParameter = errors.New("invalid parameter")
+
+// directionNames are the JSON keys of the neighbors, indexed by geohash.Direction.
+var directionNames = [...]string{"n", "ne", "e", "se", "s", "sw", "w", "nw"}
+
+type (
+	// Option configures the handler returned by NewHandler.
+	Option func(*config)
+
+	// config holds the settings of a handler.
+	config struct {
+		maxCoverCells uint64
+	}
+
+	// BBoxResponse is the JSON form of a bounding box.
+	BBoxResponse struct {
+		MinLatitude  float64 `json:"min_lat"`
+		MinLongitude float64 `json:"min_lng"`
+		MaxLatitude  float64 `json:"max_lat"`
+		MaxLongitude float64 `json:"max_lng"`
+	}
+
+	// EncodeResponse is the response of the /encode endpoint.
+	EncodeResponse struct {
+		Hash string `json:"hash"`
+	}
+
+	// DecodeResponse is the response of the /decode endpoint.
+	DecodeResponse struct {
+		Hash      string  `json:"hash"`
+		Latitude  float64 `json:"lat"`
+		Longitude float64 `json:"lng"`
+	}
+
+	// CellResponse is the response of the /bbox endpoint.
+	CellResponse struct {
+		Hash      string       `json:"hash"`
+		Latitude  float64      `json:"lat"`
+		Longitude float64      `json:"lng"`
+		BBox      BBoxResponse `json:"bbox"`
+	}
+
+	// NeighborsResponse is the response of the /neighbors endpoint, keyed by lower-case direction.
+	NeighborsResponse struct {
+		Hash      string            `json:"hash"`
+		Neighbors map[string]string `json:"neighbors"`
+	}
+
+	// CoverResponse is the response of the /cover endpoint.
+	CoverResponse struct {
+		Hashes []string `json:"hashes"`
+	}
+
+	// DistanceResponse is the response of the /distance endpoint.
+	DistanceResponse struct {
+		Meters float64 `json:"meters"`
+	}
+
+	// ErrorResponse is the body of every error response.
+	ErrorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// NewHandler returns an http.Handler serving the GET endpoints /encode, /decode, /bbox, /neighbors,
+// /cover and /distance. Responses are JSON, or GeoJSON for /decode, /bbox, /neighbors and /cover when
+// the format query parameter is "geojson" or the request accepts application/geo+json.
+// Invalid input, including coverings larger than DefaultMaxCoverCells unless set with WithMaxCoverCells,
+// is answered with 400 Bad Request and an ErrorResponse body.
+func NewHandler(opts ...Option) http.Handler {
+	cfg := config{maxCoverCells: DefaultMaxCoverCells}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/encode", get(handleEncode))
+	mux.Handle("/decode", get(handleDecode))
+	mux.Handle("/bbox", get(handleBBox))
+	mux.Handle("/neighbors", get(handleNeighbors))
+	mux.Handle("/cover", get(cfg.handleCover))
+	mux.Handle("/distance", get(handleDistance))
+	return mux
+}
+
+// WithMaxCoverCells sets the largest number of cells the /cover endpoint returns. Larger coverings are
+// rejected before they are generated. A non-positive n keeps DefaultMaxCoverCells.
+func WithMaxCoverCells(n int) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.maxCoverCells = uint64(n)
+		}
+	}
+}
+
+// handlerFunc is an endpoint returning its response body or an error.
+type handlerFunc func(r *http.Request) (any, error)
+
+// get adapts an endpoint into an http.Handler accepting GET and HEAD requests only.
+func get(h handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeJSON(w, contentTypeJSON, http.StatusMethodNotAllowed, ErrorResponse{Error: "method not allowed"})
+			return
+		}
+
+		body, err := h(r)
+		if err != nil {
+			writeJSON(w, contentTypeJSON, statusCode(err), ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		contentType := contentTypeJSON
+		if _, ok := body.(geoJSON); ok {
+			contentType = contentTypeGeoJSON
+		}
+		writeJSON(w, contentType, http.StatusOK, body)
+	})
+}
+
+func handleEncode(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	lat, err := floatParam(q.Get("lat"), "lat")
+	if err != nil {
+		return nil, err
+	}
+	lng, err := floatParam(q.Get("lng"), "lng")
+	if err != nil {
+		return nil, err
+	}
+	precision, err := precisionParam(q.Get("precision"))
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := geohash.Encode(lat, lng, precision)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeResponse{Hash: hash}, nil
+}
+
+func handleDecode(r *http.Request) (any, error) {
+	hash := r.URL.Query().Get("hash")
+	lat, lng, err := geohash.Decode(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if wantsGeoJSON(r) {
+		return pointFeature(lat, lng, map[string]any{"hash": hash}), nil
+	}
+	return DecodeResponse{Hash: hash, Latitude: lat, Longitude: lng}, nil
+}
+
+func handleBBox(r *http.Request) (any, error) {
+	hash := r.URL.Query().Get("hash")
+	lat, lng, bbox, err := geohash.DecodeBBox(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if wantsGeoJSON(r) {
+		return cellFeature(hash, bbox), nil
+	}
+	return CellResponse{Hash: hash, Latitude: lat, Longitude: lng, BBox: bboxResponse(bbox)}, nil
+}
+
+func handleNeighbors(r *http.Request) (any, error) {
+	hash := r.URL.Query().Get("hash")
+	neighbors, err := geohash.Neighbors(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if wantsGeoJSON(r) {
+		return cellCollection(neighbors), nil
+	}
+
+	resp := NeighborsResponse{Hash: hash, Neighbors: make(map[string]string, len(neighbors))}
+	for dir, neighbor := range neighbors {
+		resp.Neighbors[directionNames[dir]] = neighbor
+	}
+	return resp, nil
+}
+
+func (c config) handleCover(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	var bbox geohash.BBox
+	for _, p := range []struct {
+		name string
+		dst  *float64
+	}{
+		{"min_lat", &bbox.MinLatitude},
+		{"min_lng", &bbox.MinLongitude},
+		{"max_lat", &bbox.MaxLatitude},
+		{"max_lng", &bbox.MaxLongitude},
+	} {
+		v, err := floatParam(q.Get(p.name), p.name)
+		if err != nil {
+			return nil, err
+		}
+		*p.dst = v
+	}
+	precision, err := precisionParam(q.Get("precision"))
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := geohash.CoverSize(bbox, precision)
+	if err != nil {
+		return nil, err
+	}
+	if size > c.maxCoverCells {
+		return nil, fmt.Errorf("%w: %d cells exceed the limit of %d", geohash.ErrCoverTooLarge, size, c.maxCoverCells)
+	}
+
+	hashes, err := geohash.Cover(bbox, precision)
+	if err != nil {
+		return nil, err
+	}
+
+	if wantsGeoJSON(r) {
+		return cellCollection(hashes), nil
+	}
+	return CoverResponse{Hashes: hashes}, nil
+}
+
+func handleDistance(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	var coords [4]float64
+	for i, name := range []string{"lat1", "lng1", "lat2", "lng2"} {
+		v, err := floatParam(q.Get(name), name)
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = v
+	}
+	if err := validatePoint(coords[0], coords[1]); err != nil {
+		return nil, err
+	}
+	if err := validatePoint(coords[2], coords[3]); err != nil {
+		return nil, err
+	}
+
+	return DistanceResponse{Meters: geohash.Distance(coords[0], coords[1], coords[2], coords[3])}, nil
+}
+
+// validatePoint reports whether the coordinates are valid by encoding them at the coarsest precision.
+func validatePoint(lat, lng float64) error {
+	_, err := geohash.Encode(lat, lng, geohash.Global)
+	return err
+}
+
+// floatParam parses a required floating-point query parameter.
+func floatParam(value, name string) (float64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("%w: %s is required", errInvalidParameter, name)
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s is not a number", errInvalidParameter, name)
+	}
+	return v, nil
+}
+
+// precisionParam parses the required precision query parameter.
+func precisionParam(value string) (geohash.Precision, error) {
+	if value == "" {
+		return 0, fmt.Errorf("%w: precision is required", errInvalidParameter)
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: precision is not an integer", errInvalidParameter)
+	}
+	return geohash.Precision(v), nil
+}
+
+// wantsGeoJSON reports whether the client asked for a GeoJSON response.
+func wantsGeoJSON(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "geojson" ||
+		strings.Contains(r.Header.Get("Accept"), contentTypeGeoJSON)
+}
+
+// statusCode maps an error to its HTTP status code.
+func statusCode(err error) int {
+	for _, target := range clientErrors {
+		if errors.Is(err, target) {
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON writes a JSON body with the given content type and status code.
+func writeJSON(w http.ResponseWriter, contentType string, status int, body any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// bboxResponse converts a bounding box to its JSON form.
+func bboxResponse(bbox geohash.BBox) BBoxResponse {
+	return BBoxResponse{
+		MinLatitude:  bbox.MinLatitude,
+		MinLongitude: bbox.MinLongitude,
+		MaxLatitude:  bbox.MaxLatitude,
+		MaxLongitude: bbox.MaxLongitude,
+	}
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "Encode",
+			target:          "/encode?lat=37.7749&lng=-122.4194&precision=5",
+			wantStatus:      http.StatusOK,
+			wantContentType: contentTypeJSON,
+			wantBody:        `{"hash":"9q8yy"}`,
+		},
+		{
+			name:            "Encode missing parameter",
+			target:          "/encode?lat=37.7749&precision=5",
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: contentTypeJSON,
+			wantBody:        `{"error":"invalid parameter: lng is required"}`,
+		},
+		{
+			name:       "Encode malformed parameter",
+			target:     "/encode?lat=north&lng=0&precision=5",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid parameter: lat is not a number"}`,
+		},
+		{
+			name:       "Encode latitude out of range",
+			target:     "/encode?lat=91&lng=0&precision=5",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"latitude out of range: 91 not in [-90, 90]"}`,
+		},
+		{
+			name:       "Encode NaN",
+			target:     "/encode?lat=NaN&lng=0&precision=5",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid coordinate: latitude is NaN"}`,
+		},
+		{
+			name:       "Encode precision out of range",
+			target:     "/encode?lat=0&lng=0&precision=26",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"precision out of range: 26 not in [1, 25]"}`,
+		},
+		{
+			name:       "Decode",
+			target:     "/decode?hash=s",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"hash":"s","lat":22.5,"lng":22.5}`,
+		},
+		{
+			name:            "Decode GeoJSON",
+			target:          "/decode?hash=s&format=geojson",
+			wantStatus:      http.StatusOK,
+			wantContentType: contentTypeGeoJSON,
+			wantBody:        `{"type":"Feature","geometry":{"type":"Point","coordinates":[22.5,22.5]},"properties":{"hash":"s"}}`,
+		},
+		{
+			name:       "Decode invalid hash",
+			target:     "/decode?hash=9q8ay",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid hash format: character 'a' at position 3"}`,
+		},
+		{
+			name:       "Decode missing hash",
+			target:     "/decode",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid hash length: 0 not in [1, 25]"}`,
+		},
+		{
+			name:       "BBox",
+			target:     "/bbox?hash=s",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"hash":"s","lat":22.5,"lng":22.5,"bbox":{"min_lat":0,"min_lng":0,"max_lat":45,"max_lng":45}}`,
+		},
+		{
+			name:            "BBox GeoJSON by Accept header",
+			target:          "/bbox?hash=s",
+			accept:          "application/geo+json",
+			wantStatus:      http.StatusOK,
+			wantContentType: contentTypeGeoJSON,
+			wantBody: `{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[45,0],[45,45],[0,45],[0,0]]]},` +
+				`"properties":{"hash":"s"}}`,
+		},
+		{
+			name:       "Neighbors",
+			target:     "/neighbors?hash=9q8yy",
+			wantStatus: http.StatusOK,
+			wantBody: `{"hash":"9q8yy","neighbors":{"e":"9q8yz","n":"9q8zn","ne":"9q8zp","nw":"9q8zj",` +
+				`"s":"9q8yw","se":"9q8yx","sw":"9q8yt","w":"9q8yv"}}`,
+		},
+		{
+			name:       "Cover",
+			target:     "/cover?min_lat=-10&min_lng=-10&max_lat=10&max_lng=10&precision=1",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"hashes":["7","e","k","s"]}`,
+		},
+		{
+			name:       "Cover invalid bounding box",
+			target:     "/cover?min_lat=10&min_lng=-10&max_lat=-10&max_lng=10&precision=1",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid bounding box"}`,
+		},
+		{
+			name:       "Cover too large",
+			target:     "/cover?min_lat=-90&min_lng=-180&max_lat=90&max_lng=180&precision=6",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"cover too large: 1073741824 cells exceed the limit of 10000"}`,
+		},
+		{
+			name:       "Cover above the server limit",
+			target:     "/cover?min_lat=-10&min_lng=-10&max_lat=10&max_lng=10&precision=5",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"cover too large: 207936 cells exceed the limit of 10000"}`,
+		},
+		{
+			name:       "Distance",
+			target:     "/distance?lat1=0&lng1=0&lat2=0&lng2=1",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"meters":111195.0802335329}`,
+		},
+		{
+			name:       "Distance out of range",
+			target:     "/distance?lat1=0&lng1=0&lat2=0&lng2=181",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"longitude out of range: 181 not in [-180, 180]"}`,
+		},
+		{
+			name:       "Method not allowed",
+			method:     http.MethodPost,
+			target:     "/encode?lat=0&lng=0&precision=5",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   `{"error":"method not allowed"}`,
+		},
+	}
+
+	handler := NewHandler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, rec.Header().Get("Content-Type"))
+			}
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandlerGeoJSONCollection(t *testing.T) {
+	srv := httptest.NewServer(NewHandler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/cover?min_lat=-10&min_lng=-10&max_lat=10&max_lng=10&precision=1&format=geojson")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, contentTypeGeoJSON, resp.Header.Get("Content-Type"))
+
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type string `json:"type"`
+			} `json:"geometry"`
+			Properties struct {
+				Hash string `json:"hash"`
+			} `json:"properties"`
+		} `json:"features"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&fc))
+	assert.Equal(t, "FeatureCollection", fc.Type)
+
+	var hashes []string
+	for _, f := range fc.Features {
+		assert.Equal(t, "Polygon", f.Geometry.Type)
+		hashes = append(hashes, f.Properties.Hash)
+	}
+	assert.Equal(t, "7,e,k,s", strings.Join(hashes, ","))
+
+	resp, err = http.Get(srv.URL + "/unknown")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandlerMaxCoverCells(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxCoverCells int
+		wantStatus    int
+	}{
+		{name: "Within the limit", maxCoverCells: 4, wantStatus: http.StatusOK},
+		{name: "Above the limit", maxCoverCells: 3, wantStatus: http.StatusBadRequest},
+		{name: "Non-positive keeps the default", maxCoverCells: 0, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(WithMaxCoverCells(tt.maxCoverCells))
+			req := httptest.NewRequest(http.MethodGet, "/cover?min_lat=-10&min_lng=-10&max_lat=10&max_lng=10&precision=1&format=geojson", nil)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package geohash
+
+type (
+	// Set is a set of GeoHash cells backed by a 32-ary trie. Cells are kept normalized: cells contained in
+	// another cell are absorbed, and complete groups of 32 siblings collapse into their parent, as in Compact.
+	// The zero value is an empty set ready to use.
+	Set struct {
+		root setNode
+	}
+
+	// setNode is a trie node; a full node covers its whole cell, otherwise its children cover parts of it.
+	setNode struct {
+		full     bool
+		children [len(alphabet)]*setNode
+	}
+)
+
+// NewSet creates a Set holding the given GeoHash cells.
+// Returns an error if any hash is invalid.
+func NewSet(hashes ...string) (*Set, error) {
+	s := &Set{}
+	for _, hash := range hashes {
+		if err := s.Add(hash); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// MustNewSet creates a Set holding the given GeoHash cells or panics if an error occurs.
+func MustNewSet(hashes ...string) *Set {
+	s, err := NewSet(hashes...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Add inserts the cell identified by hash into the set.
+// Returns an error if the hash is invalid.
+func (s *Set) Add(hash string) error {
+	if err := validateHash(hash); err != nil {
+		return err
+	}
+
+	s.root.add(hash, 0)
+	return nil
+}
+
+// Remove removes the area of the cell identified by hash from the set, splitting coarser cells if needed.
+// Returns an error if the hash is invalid.
+func (s *Set) Remove(hash string) error {
+	if err := validateHash(hash); err != nil {
+		return err
+	}
+
+	s.root.remove(hash, 0)
+	return nil
+}
+
+// Contains reports whether the cell identified by hash lies within the set, that is whether the hash
+// or any of its prefixes is present. Invalid hashes are never contained.
+func (s *Set) Contains(hash string) bool {
+	if validateHash(hash) != nil {
+		return false
+	}
+
+	n := &s.root
+	for i := 0; i < len(hash); i++ {
+		n = n.children[StdEncoding.decodeMap[hash[i]]]
+		if n == nil {
+			return false
+		}
+		if n.full {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsPoint reports whether the given coordinates fall within one of the cells of the set.
+func (s *Set) ContainsPoint(latitude, longitude float64) bool {
+	hash, err := Encode(latitude, longitude, SubPoint)
+	if err != nil {
+		return false
+	}
+	return s.Contains(hash)
+}
+
+// Len returns the number of normalized cells in the set.
+func (s *Set) Len() int {
+	count := 0
+	s.Walk(func(string) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Hashes returns the normalized cells of the set in sorted order.
+func (s *Set) Hashes() []string {
+	var hashes []string
+	s.Walk(func(hash string) bool {
+		hashes = append(hashes, hash)
+		return true
+	})
+	return hashes
+}
+
+// Walk calls fn for each normalized cell of the set in sorted order, stopping early if fn returns false.
+func (s *Set) Walk(fn func(hash string) bool) {
+	var buf [SubPoint]byte
+	s.root.walk(buf[:0], fn)
+}
+
+// Union returns a new set holding the cells present in either set.
+func (s *Set) Union(other *Set) *Set {
+	return &Set{root: *union(&s.root, &other.root, 0)}
+}
+
+// Intersection returns a new set holding the area present in both sets.
+func (s *Set) Intersection(other *Set) *Set {
+	root := intersection(&s.root, &other.root, 0)
+	if root == nil {
+		return &Set{}
+	}
+	return &Set{root: *root}
+}
+
+// Difference returns a new set holding the area of s that is not present in other.
+func (s *Set) Difference(other *Set) *Set {
+	root := difference(&s.root, &other.root, 0)
+	if root == nil {
+		return &Set{}
+	}
+	return &Set{root: *root}
+}
+
+// add marks the cell identified by hash[depth:] below n as full.
+func (n *setNode) add(hash string, depth int) {
+	if n.full {
+		return
+	}
+	if depth == len(hash) {
+		n.full = true
+		n.children = [len(alphabet)]*setNode{}
+		return
+	}
+
+	index := StdEncoding.decodeMap[hash[depth]]
+	if n.children[index] == nil {
+		n.children[index] = &setNode{}
+	}
+	n.children[index].add(hash, depth+1)
+	n.normalize(depth)
+}
+
+// remove clears the cell identified by hash[depth:] below n and reports whether n became empty.
+func (n *setNode) remove(hash string, depth int) bool {
+	if depth == len(hash) {
+		*n = setNode{}
+		return true
+	}
+	if n.full {
+		n.split()
+	}
+
+	index := StdEncoding.decodeMap[hash[depth]]
+	child := n.children[index]
+	if child == nil {
+		return n.empty()
+	}
+	if child.remove(hash, depth+1) {
+		n.children[index] = nil
+	}
+	return n.empty()
+}
+
+// walk visits the full cells below n in sorted order and reports whether the walk should continue.
+func (n *setNode) walk(prefix []byte, fn func(hash string) bool) bool {
+	if n.full {
+		return fn(string(prefix))
+	}
+	for i, child := range n.children {
+		if child != nil && !child.walk(append(prefix, alphabet[i]), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// split replaces a full node with 32 full children.
+func (n *setNode) split() {
+	n.full = false
+	for i := range n.children {
+		n.children[i] = &setNode{full: true}
+	}
+}
+
+// normalize collapses a node whose children are all full into a full node.
+// The root (depth 0) never collapses since it does not correspond to a GeoHash.
+func (n *setNode) normalize(depth int) {
+	if depth == 0 {
+		return
+	}
+	for _, child := range n.children {
+		if child == nil || !child.full {
+			return
+		}
+	}
+	n.full = true
+	n.children = [len(alphabet)]*setNode{}
+}
+
+// empty reports whether the node covers no area.
+func (n *setNode) empty() bool {
+	if n.full {
+		return false
+	}
+	for _, child := range n.children {
+		if child != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns a deep copy of the node.
+func (n *setNode) clone() *setNode {
+	c := &setNode{full: n.full}
+	for i, child := range n.children {
+		if child != nil {
+			c.children[i] = child.clone()
+		}
+	}
+	return c
+}
+
+// union returns a new node covering the area of a or b; both may be nil.
+func union(a, b *setNode, depth int) *setNode {
+	switch {
+	case a == nil && b == nil:
+		return nil
+	case a == nil:
+		return b.clone()
+	case b == nil:
+		return a.clone()
+	case a.full || b.full:
+		return &setNode{full: true}
+	}
+
+	n := &setNode{}
+	for i := range n.children {
+		n.children[i] = union(a.children[i], b.children[i], depth+1)
+	}
+	n.normalize(depth)
+	return n
+}
+
+// intersection returns a new node covering the area of both a and b, or nil if they do not overlap.
+func intersection(a, b *setNode, depth int) *setNode {
+	switch {
+	case a == nil || b == nil:
+		return nil
+	case a.full:
+		return b.clone()
+	case b.full:
+		return a.clone()
+	}
+
+	n := &setNode{}
+	for i := range n.children {
+		n.children[i] = intersection(a.children[i], b.children[i], depth+1)
+	}
+	if n.empty() {
+		return nil
+	}
+	n.normalize(depth)
+	return n
+}
+
+// difference returns a new node covering the area of a outside b, or nil if nothing remains.
+func difference(a, b *setNode, depth int) *setNode {
+	switch {
+	case a == nil || (b != nil && b.full):
+		return nil
+	case b == nil:
+		return a.clone()
+	}
+
+	n := &setNode{}
+	for i := range n.children {
+		child := a.children[i]
+		if a.full {
+			child = &setNode{full: true}
+		}
+		n.children[i] = difference(child, b.children[i], depth+1)
+	}
+	if n.empty() {
+		return nil
+	}
+	n.normalize(depth)
+	return n
+}
@@ -0,0 +1,186 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func siblings(parent string) []string {
+	hashes := make([]string, 0, len(alphabet))
+	for _, c := range alphabet {
+		hashes = append(hashes, parent+string(c))
+	}
+	return hashes
+}
+
+func TestNewSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		hashes  []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Invalid hash - empty",
+			hashes:  []string{""},
+			wantErr: ErrInvalidHashLength,
+		},
+		{
+			name:    "Invalid hash - invalid characters",
+			hashes:  []string{"9q8yy!"},
+			wantErr: ErrInvalidHashFormat,
+		},
+		{
+			name:   "Empty set",
+			hashes: nil,
+			want:   nil,
+		},
+		{
+			name:   "Sorted and normalized",
+			hashes: []string{"dr5", "9q8yy", "9q8", "9q8"},
+			want:   []string{"9q8", "dr5"},
+		},
+		{
+			name:   "Complete siblings collapse recursively",
+			hashes: append(siblings("9q8")[1:], siblings("9q80")...),
+			want:   []string{"9q8"},
+		},
+		{
+			name:   "Top level cells never collapse",
+			hashes: siblings(""),
+			want:   siblings(""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSet(tt.hashes...)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got.Hashes())
+			assert.Equal(t, len(tt.want), got.Len())
+		})
+	}
+}
+
+func TestMustNewSet(t *testing.T) {
+	assert.Panics(t, func() { MustNewSet("a") })
+	assert.Equal(t, []string{"9q8"}, MustNewSet("9q8").Hashes())
+}
+
+func TestSetContains(t *testing.T) {
+	s := MustNewSet("9q8", "dr5ru")
+
+	tests := []struct {
+		name string
+		hash string
+		want bool
+	}{
+		{name: "Exact cell", hash: "9q8", want: true},
+		{name: "Descendant cell", hash: "9q8yyk", want: true},
+		{name: "Ancestor cell", hash: "9q", want: false},
+		{name: "Partially covered ancestor", hash: "dr5r", want: false},
+		{name: "Unrelated cell", hash: "u4pru", want: false},
+		{name: "Invalid hash", hash: "9q8!", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.Contains(tt.hash))
+		})
+	}
+
+	assert.True(t, s.ContainsPoint(37.7749, -122.4194))
+	assert.False(t, s.ContainsPoint(48.8566, 2.3522))
+	assert.False(t, s.ContainsPoint(91, 0))
+}
+
+func TestSetRemove(t *testing.T) {
+	s := MustNewSet("9q8", "dr5")
+
+	assert.ErrorIs(t, s.Remove("9q8!"), ErrInvalidHashFormat)
+
+	assert.NoError(t, s.Remove("9q8y"))
+	assert.False(t, s.Contains("9q8y"))
+	assert.False(t, s.Contains("9q8"))
+	assert.True(t, s.Contains("9q8z"))
+	assert.Equal(t, 32, s.Len())
+
+	assert.NoError(t, s.Remove("dr"))
+	assert.NoError(t, s.Remove("u4"))
+	assert.Equal(t, 31, s.Len())
+
+	assert.NoError(t, s.Add("9q8y"))
+	assert.Equal(t, []string{"9q8"}, s.Hashes())
+}
+
+func TestSetOperations(t *testing.T) {
+	a := MustNewSet("9q8", "dr5")
+	b := MustNewSet("9q8y", "u4pr")
+
+	tests := []struct {
+		name string
+		got  *Set
+		want []string
+	}{
+		{
+			name: "Union",
+			got:  a.Union(b),
+			want: []string{"9q8", "dr5", "u4pr"},
+		},
+		{
+			name: "Union completing siblings",
+			got:  MustNewSet(siblings("9q8")[1:]...).Union(MustNewSet("9q80")),
+			want: []string{"9q8"},
+		},
+		{
+			name: "Intersection",
+			got:  a.Intersection(b),
+			want: []string{"9q8y"},
+		},
+		{
+			name: "Intersection - disjoint",
+			got:  MustNewSet("dr5").Intersection(MustNewSet("u4pr")),
+			want: nil,
+		},
+		{
+			name: "Difference",
+			got:  MustNewSet("9q8y", "dr5").Difference(MustNewSet("9q8yy", "dr")),
+			want: []string{
+				"9q8y0", "9q8y1", "9q8y2", "9q8y3", "9q8y4", "9q8y5", "9q8y6", "9q8y7",
+				"9q8y8", "9q8y9", "9q8yb", "9q8yc", "9q8yd", "9q8ye", "9q8yf", "9q8yg",
+				"9q8yh", "9q8yj", "9q8yk", "9q8ym", "9q8yn", "9q8yp", "9q8yq", "9q8yr",
+				"9q8ys", "9q8yt", "9q8yu", "9q8yv", "9q8yw", "9q8yx", "9q8yz",
+			},
+		},
+		{
+			name: "Difference - nothing left",
+			got:  MustNewSet("9q8y").Difference(MustNewSet("9q")),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got.Hashes())
+		})
+	}
+
+	// Operations must not alter their operands.
+	assert.Equal(t, []string{"9q8", "dr5"}, a.Hashes())
+	assert.Equal(t, []string{"9q8y", "u4pr"}, b.Hashes())
+}
+
+func TestSetWalk(t *testing.T) {
+	s := MustNewSet("dr5", "9q8", "u4pr")
+
+	var got []string
+	s.Walk(func(hash string) bool {
+		got = append(got, hash)
+		return len(got) < 2
+	})
+	assert.Equal(t, []string{"9q8", "dr5"}, got)
+}
@@ -0,0 +1,216 @@
+package geohash
+
+import (
+	"errors"
+	"math/bits"
+	"time"
+)
+
+var (
+	// ErrInvalidSpan is returned when the time span of a Spacetime is not positive.
+	ErrInvalidSpan = errors.New("invalid span")
+
+	// ErrTimeOutOfRange is returned when an instant falls outside the span of a Spacetime.
+	ErrTimeOutOfRange = errors.New("time out of range")
+)
+
+type (
+	// Spacetime encodes locations along with instants into geotemporal hashes. The bits of longitude,
+	// latitude, and time are interleaved in turn, starting from longitude as in a GeoHash, and written with
+	// the GeoHash alphabet, so that every prefix of a geotemporal hash identifies a box of space and time.
+	// Time is partitioned like a coordinate over [epoch, epoch+span).
+	Spacetime struct {
+		epoch time.Time
+		span  time.Duration
+	}
+
+	// Interval is the half-open time range [Start, End).
+	Interval struct {
+		Start time.Time
+		End   time.Time
+	}
+)
+
+// spacetimeAxes is the number of dimensions of a geotemporal hash: longitude, latitude, and time.
+const spacetimeAxes = 3
+
+// NewSpacetime creates a Spacetime partitioning the time range [epoch, epoch+span).
+// Returns an error if the span is not positive.
+func NewSpacetime(epoch time.Time, span time.Duration) (*Spacetime, error) {
+	if span <= 0 {
+		return nil, ErrInvalidSpan
+	}
+	return &Spacetime{epoch: epoch, span: span}, nil
+}
+
+// MustNewSpacetime creates a Spacetime or panics if an error occurs.
+func MustNewSpacetime(epoch time.Time, span time.Duration) *Spacetime {
+	s, err := NewSpacetime(epoch, span)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Epoch returns the start of the time range.
+func (s *Spacetime) Epoch() time.Time {
+	return s.epoch
+}
+
+// Span returns the length of the time range.
+func (s *Spacetime) Span() time.Duration {
+	return s.span
+}
+
+// Encode generates a geotemporal hash for the given coordinates and instant at the given precision (1 to SubPoint).
+// Returns an error if the latitude, longitude, instant, or precision is out of the valid range.
+func (s *Spacetime) Encode(latitude, longitude float64, t time.Time, precision Precision) (string, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
+	}
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return "", err
+	}
+	offset := t.Sub(s.epoch)
+	if t.Before(s.epoch) || offset >= s.span {
+		return "", ErrTimeOutOfRange
+	}
+
+	axes := axesBits(int(precision)*bitsPerChar, spacetimeAxes)
+	bitset := interleaveAxes([]uint64{
+		encodeAxis(minLongitude, maxLongitude, longitude, axes[0]),
+		encodeAxis(minLatitude, maxLatitude, latitude, axes[1]),
+		encodeTimeAxis(offset, s.span, axes[2]),
+	}, axes)
+
+	return encodeToBase32(bitset, precision), nil
+}
+
+// MustEncode generates a geotemporal hash or panics if an error occurs.
+func (s *Spacetime) MustEncode(latitude, longitude float64, t time.Time, precision Precision) string {
+	hash, err := s.Encode(latitude, longitude, t, precision)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// Decode takes a geotemporal hash and returns the center of its box of space and time.
+// Returns an error if the hash is invalid.
+func (s *Spacetime) Decode(hash string) (latitude, longitude float64, t time.Time, err error) {
+	latitude, longitude, t, _, _, err = s.DecodeBBox(hash)
+	return latitude, longitude, t, err
+}
+
+// MustDecode decodes a geotemporal hash or panics if an error occurs.
+func (s *Spacetime) MustDecode(hash string) (latitude, longitude float64, t time.Time) {
+	latitude, longitude, t, err := s.Decode(hash)
+	if err != nil {
+		panic(err)
+	}
+	return latitude, longitude, t
+}
+
+// DecodeBBox decodes a geotemporal hash into the center of its box of space and time, along with the
+// bounding box and time interval of the box.
+// Returns an error if the hash is invalid.
+func (s *Spacetime) DecodeBBox(hash string) (latitude, longitude float64, t time.Time, bbox BBox, interval Interval, err error) {
+	if err := validateHash(hash); err != nil {
+		return 0, 0, time.Time{}, BBox{}, Interval{}, err
+	}
+
+	bitset, precision, _ := decodeFromBase32(hash)
+	axes := axesBits(int(precision)*bitsPerChar, spacetimeAxes)
+	values := deinterleaveAxes(bitset, axes)
+
+	bbox.MinLongitude, bbox.MaxLongitude = decodeAxis(minLongitude, maxLongitude, values[0], axes[0])
+	bbox.MinLatitude, bbox.MaxLatitude = decodeAxis(minLatitude, maxLatitude, values[1], axes[1])
+	start, end := decodeTimeAxis(values[2], s.span, axes[2])
+	interval = Interval{Start: s.epoch.Add(start), End: s.epoch.Add(end)}
+
+	latitude = (bbox.MinLatitude + bbox.MaxLatitude) / 2
+	longitude = (bbox.MinLongitude + bbox.MaxLongitude) / 2
+	t = interval.Start.Add(interval.Duration() / 2)
+	return latitude, longitude, t, bbox, interval, nil
+}
+
+// MustDecodeBBox decodes a geotemporal hash into its box of space and time or panics if an error occurs.
+func (s *Spacetime) MustDecodeBBox(hash string) (latitude, longitude float64, t time.Time, bbox BBox, interval Interval) {
+	latitude, longitude, t, bbox, interval, err := s.DecodeBBox(hash)
+	if err != nil {
+		panic(err)
+	}
+	return latitude, longitude, t, bbox, interval
+}
+
+// Contains reports whether the instant lies within the interval.
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Duration returns the length of the interval.
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// encodeTimeAxis returns the index of the slice holding offset when span is split into 2^depth slices.
+func encodeTimeAxis(offset, span time.Duration, depth int) uint64 {
+	hi, lo := bits.Mul64(uint64(offset), uint64(1)<<depth)
+	index, _ := bits.Div64(hi, lo, uint64(span))
+	return index
+}
+
+// decodeTimeAxis returns the offsets bounding the slice of the given index when span is split into 2^depth slices.
+func decodeTimeAxis(index uint64, span time.Duration, depth int) (start, end time.Duration) {
+	bound := func(index uint64) time.Duration {
+		hi, lo := bits.Mul64(index, uint64(span))
+		return time.Duration(lo>>depth | hi<<(64-depth))
+	}
+	return bound(index), bound(index + 1)
+}
+
+// axesBits splits totalBits among the given number of axes, taking one bit from each axis in turn, so the
+// first axes get the remaining bits.
+func axesBits(totalBits, axes int) []int {
+	result := make([]int, axes)
+	for i := range result {
+		result[i] = totalBits / axes
+		if i < totalBits%axes {
+			result[i]++
+		}
+	}
+	return result
+}
+
+// interleaveAxes merges the bitsets of several axes, most significant bits first, taking one bit from each
+// axis in turn.
+func interleaveAxes(values []uint64, axes []int) uint64 {
+	remaining := append([]int(nil), axes...)
+	total := 0
+	for _, n := range axes {
+		total += n
+	}
+
+	var bitset uint64
+	for i := 0; i < total; i++ {
+		axis := i % len(axes)
+		remaining[axis]--
+		bitset = bitset<<1 | (values[axis]>>remaining[axis])&1
+	}
+	return bitset
+}
+
+// deinterleaveAxes splits a bitset built by interleaveAxes into the bitsets of its axes.
+func deinterleaveAxes(bitset uint64, axes []int) []uint64 {
+	total := 0
+	for _, n := range axes {
+		total += n
+	}
+
+	values := make([]uint64, len(axes))
+	for i := 0; i < total; i++ {
+		axis := i % len(axes)
+		values[axis] = values[axis]<<1 | (bitset>>(total-1-i))&1
+	}
+	return values
+}
@@ -0,0 +1,159 @@
+package geohash
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	spacetimeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	spacetimeYear  = MustNewSpacetime(spacetimeEpoch, 365*24*time.Hour)
+)
+
+func TestNewSpacetime(t *testing.T) {
+	_, err := NewSpacetime(spacetimeEpoch, 0)
+	assert.ErrorIs(t, err, ErrInvalidSpan)
+
+	_, err = NewSpacetime(spacetimeEpoch, -time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidSpan)
+
+	assert.Panics(t, func() { MustNewSpacetime(spacetimeEpoch, 0) })
+
+	s, err := NewSpacetime(spacetimeEpoch, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, spacetimeEpoch, s.Epoch())
+	assert.Equal(t, time.Hour, s.Span())
+}
+
+func TestSpacetimeEncode(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		t         time.Time
+		precision Precision
+		want      string
+		wantErr   error
+	}{
+		{name: "Global", latitude: 37.7749, longitude: -122.4194, t: at, precision: Global, want: "8"},
+		{name: "Region", latitude: 37.7749, longitude: -122.4194, t: at, precision: Region, want: "8wwd"},
+		{name: "Block", latitude: 37.7749, longitude: -122.4194, t: at, precision: Block, want: "8wwd7nzq"},
+		{name: "SubPoint", latitude: 37.7749, longitude: -122.4194, t: at, precision: SubPoint, want: "8wwd7nzq5d5w"},
+		{name: "Start of the span", latitude: -90, longitude: -180, t: spacetimeEpoch, precision: Street, want: "000000"},
+		{
+			name:      "End of the span",
+			latitude:  90,
+			longitude: 180,
+			t:         spacetimeEpoch.Add(365*24*time.Hour - 1),
+			precision: Street,
+			want:      "zzzzzz",
+		},
+		{name: "Before the epoch", latitude: 0, longitude: 0, t: spacetimeEpoch.Add(-1), precision: Street, wantErr: ErrTimeOutOfRange},
+		{
+			name:      "After the span",
+			latitude:  0,
+			longitude: 0,
+			t:         spacetimeEpoch.Add(365 * 24 * time.Hour),
+			precision: Street,
+			wantErr:   ErrTimeOutOfRange,
+		},
+		{name: "Latitude out of range", latitude: 91, longitude: 0, t: at, precision: Street, wantErr: ErrLatitudeOutOfRange},
+		{name: "Precision out of range", latitude: 0, longitude: 0, t: at, precision: SubPoint + 1, wantErr: ErrPrecisionOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spacetimeYear.Encode(tt.latitude, tt.longitude, tt.t, tt.precision)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Panics(t, func() { spacetimeYear.MustEncode(tt.latitude, tt.longitude, tt.t, tt.precision) })
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSpacetimeDecodeBBox(t *testing.T) {
+	lat, lng, at, bbox, interval, err := spacetimeYear.DecodeBBox("8")
+	require.NoError(t, err)
+	assert.Equal(t, BBox{MinLatitude: 0, MaxLatitude: 45, MinLongitude: -180, MaxLongitude: -90}, bbox)
+	assert.Equal(t, Interval{Start: spacetimeEpoch, End: time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)}, interval)
+	assert.Equal(t, 22.5, lat)
+	assert.Equal(t, -135.0, lng)
+	assert.Equal(t, time.Date(2024, 4, 1, 6, 0, 0, 0, time.UTC), at)
+
+	_, _, _, _, _, err = spacetimeYear.DecodeBBox("8wwda")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+
+	_, _, _, err = spacetimeYear.Decode("")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+
+	assert.Panics(t, func() { spacetimeYear.MustDecode("") })
+	assert.Panics(t, func() { spacetimeYear.MustDecodeBBox("") })
+}
+
+func TestSpacetimeRoundTrip(t *testing.T) {
+	s := MustNewSpacetime(spacetimeEpoch, 7*24*time.Hour+3*time.Nanosecond)
+	points := []struct {
+		latitude  float64
+		longitude float64
+		t         time.Time
+	}{
+		{37.7749, -122.4194, spacetimeEpoch.Add(36 * time.Hour)},
+		{-33.8688, 151.2093, spacetimeEpoch.Add(6*24*time.Hour + 23*time.Hour)},
+		{51.5074, -0.1278, spacetimeEpoch},
+		{-90, 180, spacetimeEpoch.Add(7*24*time.Hour + 2*time.Nanosecond)},
+	}
+
+	for _, p := range points {
+		full := s.MustEncode(p.latitude, p.longitude, p.t, SubPoint)
+		for precision := Global; precision <= SubPoint; precision++ {
+			hash := s.MustEncode(p.latitude, p.longitude, p.t, precision)
+			assert.True(t, strings.HasPrefix(full, hash), "prefix %s of %s", hash, full)
+
+			lat, lng, at, bbox, interval := s.MustDecodeBBox(hash)
+			assert.True(t, bbox.Contains(p.latitude, p.longitude))
+			assert.True(t, bbox.Contains(lat, lng))
+			assert.True(t, interval.Contains(p.t), "%v not in %v", p.t, interval)
+			assert.True(t, interval.Contains(at))
+
+			lat2, lng2, at2 := s.MustDecode(hash)
+			assert.Equal(t, [2]float64{lat, lng}, [2]float64{lat2, lng2})
+			assert.Equal(t, at, at2)
+		}
+	}
+}
+
+func TestInterleaveAxes(t *testing.T) {
+	// With longitude and latitude only, interleaving matches the GeoHash bitset.
+	for precision := Global; precision <= SubPoint; precision++ {
+		axes := axesBits(int(precision)*bitsPerChar, 2)
+		lng := encodeAxis(minLongitude, maxLongitude, -122.4194, axes[0])
+		lat := encodeAxis(minLatitude, maxLatitude, 37.7749, axes[1])
+
+		bitset := interleaveAxes([]uint64{lng, lat}, axes)
+		assert.Equal(t, MustEncode(37.7749, -122.4194, precision), encodeToBase32(bitset, precision))
+		assert.Equal(t, []uint64{lng, lat}, deinterleaveAxes(bitset, axes))
+	}
+
+	assert.Equal(t, []int{2, 2, 1}, axesBits(5, 3))
+	assert.Equal(t, []int{20, 20, 20}, axesBits(60, 3))
+	assert.Equal(t, uint64(0b101_100), interleaveAxes([]uint64{0b11, 0b00, 0b10}, []int{2, 2, 2}))
+}
+
+func TestInterval(t *testing.T) {
+	i := Interval{Start: spacetimeEpoch, End: spacetimeEpoch.Add(time.Hour)}
+	assert.Equal(t, time.Hour, i.Duration())
+	assert.True(t, i.Contains(spacetimeEpoch))
+	assert.True(t, i.Contains(spacetimeEpoch.Add(time.Hour-1)))
+	assert.False(t, i.Contains(spacetimeEpoch.Add(time.Hour)))
+	assert.False(t, i.Contains(spacetimeEpoch.Add(-1)))
+}
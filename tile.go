@@ -0,0 +1,202 @@
+package geohash
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	// maxZoom is the deepest Web Mercator zoom level accepted.
+	maxZoom = 30
+
+	// maxTiles bounds the number of tiles HashTiles is allowed to generate.
+	maxTiles = 1 << 20
+
+	// MercatorMaxLatitude is the latitude at which Web Mercator tiles are cut off (arctan(sinh(π))).
+	MercatorMaxLatitude float64 = 85.0511287798066
+)
+
+var (
+	// ErrInvalidZoom is returned when a zoom level is outside the valid range (0 to 30).
+	ErrInvalidZoom = errors.New("invalid zoom")
+
+	// ErrInvalidTile is returned when tile coordinates are outside the grid of their zoom level.
+	ErrInvalidTile = errors.New("invalid tile")
+
+	// ErrInvalidQuadkey is returned when a quadkey is too long or contains characters other than 0 to 3.
+	ErrInvalidQuadkey = errors.New("invalid quadkey")
+)
+
+// Tile identifies a Web Mercator (slippy map) tile by its column X, row Y (from the north) and zoom level Z.
+type Tile struct {
+	X int
+	Y int
+	Z int
+}
+
+// LatLngTile returns the tile containing the given coordinates at the given zoom level.
+// Latitudes beyond the Mercator cutoff are clamped to the first or last tile row.
+// Returns an error if the coordinates or zoom level are out of range.
+func LatLngTile(latitude, longitude float64, zoom int) (Tile, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return Tile{}, err
+	}
+	if zoom < 0 || zoom > maxZoom {
+		return Tile{}, ErrInvalidZoom
+	}
+
+	last := float64(int(1)<<zoom - 1)
+	x := math.Min(math.Floor(tileX(longitude, zoom)), last)
+	y := math.Min(math.Floor(tileY(latitude, zoom)), last)
+	return Tile{X: int(x), Y: int(math.Max(y, 0)), Z: zoom}, nil
+}
+
+// BBox returns the bounding box of the tile.
+func (t Tile) BBox() BBox {
+	n := float64(int(1) << t.Z)
+	return BBox{
+		MinLatitude:  tileLatitude(float64(t.Y+1), n),
+		MaxLatitude:  tileLatitude(float64(t.Y), n),
+		MinLongitude: float64(t.X)/n*360 - 180,
+		MaxLongitude: float64(t.X+1)/n*360 - 180,
+	}
+}
+
+// Quadkey returns the Bing Maps quadkey of the tile.
+func (t Tile) Quadkey() string {
+	var sb strings.Builder
+	sb.Grow(t.Z)
+	for i := t.Z; i > 0; i-- {
+		digit := byte('0')
+		mask := 1 << (i - 1)
+		if t.X&mask != 0 {
+			digit++
+		}
+		if t.Y&mask != 0 {
+			digit += 2
+		}
+		sb.WriteByte(digit)
+	}
+	return sb.String()
+}
+
+// ParseQuadkey returns the tile identified by a Bing Maps quadkey. The empty quadkey is the zoom 0 tile.
+// Returns an error if the quadkey is invalid.
+func ParseQuadkey(quadkey string) (Tile, error) {
+	if len(quadkey) > maxZoom {
+		return Tile{}, ErrInvalidQuadkey
+	}
+
+	t := Tile{Z: len(quadkey)}
+	for _, c := range quadkey {
+		if c < '0' || c > '3' {
+			return Tile{}, ErrInvalidQuadkey
+		}
+		digit := int(c - '0')
+		t.X = t.X<<1 | digit&1
+		t.Y = t.Y<<1 | digit>>1
+	}
+	return t, nil
+}
+
+// HashTiles returns the tiles at the given zoom level covering the GeoHash cell, ordered by row then column.
+// The part of the cell beyond the Mercator cutoff is ignored, so polar cells may have no tiles.
+// Returns an error if the hash or zoom level is invalid, or if too many tiles would be needed.
+func HashTiles(hash string, zoom int) ([]Tile, error) {
+	_, _, bbox, err := DecodeBBox(hash)
+	if err != nil {
+		return nil, err
+	}
+	if zoom < 0 || zoom > maxZoom {
+		return nil, ErrInvalidZoom
+	}
+
+	minLat := math.Max(bbox.MinLatitude, -MercatorMaxLatitude)
+	maxLat := math.Min(bbox.MaxLatitude, MercatorMaxLatitude)
+	if minLat >= maxLat {
+		return []Tile{}, nil
+	}
+
+	last := int(1)<<zoom - 1
+	xLo, xHi := indexSpan(tileX(bbox.MinLongitude, zoom), tileX(bbox.MaxLongitude, zoom), last)
+	yLo, yHi := indexSpan(tileY(maxLat, zoom), tileY(minLat, zoom), last)
+	if (xHi-xLo+1)*(yHi-yLo+1) > maxTiles {
+		return nil, ErrCoverTooLarge
+	}
+
+	tiles := make([]Tile, 0, (xHi-xLo+1)*(yHi-yLo+1))
+	for y := yLo; y <= yHi; y++ {
+		for x := xLo; x <= xHi; x++ {
+			tiles = append(tiles, Tile{X: x, Y: y, Z: zoom})
+		}
+	}
+	return tiles, nil
+}
+
+// TileHashes returns the sorted GeoHash cells at the given precision covering the tile.
+// Returns an error if the tile or precision is invalid, or if the covering would be too large.
+func TileHashes(tile Tile, precision Precision) ([]string, error) {
+	if tile.Z < 0 || tile.Z > maxZoom {
+		return nil, ErrInvalidZoom
+	}
+	if n := int(1) << tile.Z; tile.X < 0 || tile.X >= n || tile.Y < 0 || tile.Y >= n {
+		return nil, ErrInvalidTile
+	}
+	return Cover(tile.BBox(), precision)
+}
+
+// ZoomPrecision returns the precision whose cells best match the width of a tile at the given zoom level.
+// Returns an error if the zoom level is out of range.
+func ZoomPrecision(zoom int) (Precision, error) {
+	if zoom < 0 || zoom > maxZoom {
+		return 0, ErrInvalidZoom
+	}
+	return zoomPrecision(zoom), nil
+}
+
+// zoomPrecision returns the precision whose cells are closest in width to a tile at the given zoom level,
+// preferring the coarser precision on ties.
+func zoomPrecision(zoom int) Precision {
+	best := Global
+	bestDiff := maxZoom + 1
+	for p := Global; p <= SubPoint; p++ {
+		lngBits := (int(p)*bitsPerChar + 1) / 2
+		diff := lngBits - zoom
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff < bestDiff {
+			best, bestDiff = p, diff
+		}
+	}
+	return best
+}
+
+// tileX returns the fractional tile column of a longitude.
+func tileX(longitude float64, zoom int) float64 {
+	return (longitude + 180) / 360 * float64(int(1)<<zoom)
+}
+
+// tileY returns the fractional tile row of a latitude, growing southward.
+func tileY(latitude float64, zoom int) float64 {
+	latitude = math.Max(-MercatorMaxLatitude, math.Min(MercatorMaxLatitude, latitude))
+	phi := degToRad(latitude)
+	return (1 - math.Log(math.Tan(phi)+1/math.Cos(phi))/math.Pi) / 2 * float64(int(1)<<zoom)
+}
+
+// tileLatitude returns the latitude of the northern edge of fractional tile row y in a grid of n rows.
+func tileLatitude(y, n float64) float64 {
+	return radToDeg(math.Atan(math.Sinh(math.Pi * (1 - 2*y/n))))
+}
+
+// indexSpan returns the inclusive range of grid indexes touched by the fractional indexes [from, to],
+// where an upper edge lying exactly on a boundary does not pull in the next index.
+func indexSpan(from, to float64, last int) (lo, hi int) {
+	start := math.Floor(from)
+	end := math.Ceil(to) - 1
+	if end < start {
+		end = start
+	}
+	return min(max(int(start), 0), last), min(max(int(end), 0), last)
+}
@@ -0,0 +1,196 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatLngTile(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		zoom      int
+		want      Tile
+		wantErr   error
+	}{
+		{
+			name:     "Latitude out of range",
+			latitude: 91,
+			wantErr:  ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Longitude out of range",
+			longitude: 181,
+			wantErr:   ErrLongitudeOutOfRange,
+		},
+		{
+			name:    "Invalid zoom",
+			zoom:    31,
+			wantErr: ErrInvalidZoom,
+		},
+		{
+			name: "Zoom 0",
+			want: Tile{X: 0, Y: 0, Z: 0},
+		},
+		{
+			name:      "San Francisco at zoom 10",
+			latitude:  37.7749,
+			longitude: -122.4194,
+			zoom:      10,
+			want:      Tile{X: 163, Y: 395, Z: 10},
+		},
+		{
+			name:      "Beyond the Mercator cutoff",
+			latitude:  89,
+			longitude: 180,
+			zoom:      2,
+			want:      Tile{X: 3, Y: 0, Z: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LatLngTile(tt.latitude, tt.longitude, tt.zoom)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTileBBox(t *testing.T) {
+	got := Tile{X: 0, Y: 0, Z: 0}.BBox()
+	assert.InDelta(t, -MercatorMaxLatitude, got.MinLatitude, tolerance)
+	assert.InDelta(t, MercatorMaxLatitude, got.MaxLatitude, tolerance)
+	assert.InDelta(t, -180, got.MinLongitude, tolerance)
+	assert.InDelta(t, 180, got.MaxLongitude, tolerance)
+
+	got = Tile{X: 1, Y: 1, Z: 1}.BBox()
+	assert.InDelta(t, -MercatorMaxLatitude, got.MinLatitude, tolerance)
+	assert.InDelta(t, 0, got.MaxLatitude, tolerance)
+	assert.InDelta(t, 0, got.MinLongitude, tolerance)
+	assert.InDelta(t, 180, got.MaxLongitude, tolerance)
+}
+
+func TestQuadkey(t *testing.T) {
+	tests := []struct {
+		tile    Tile
+		quadkey string
+	}{
+		{tile: Tile{X: 0, Y: 0, Z: 0}, quadkey: ""},
+		{tile: Tile{X: 3, Y: 5, Z: 3}, quadkey: "213"},
+		{tile: Tile{X: 163, Y: 395, Z: 10}, quadkey: "0230102033"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quadkey, func(t *testing.T) {
+			assert.Equal(t, tt.quadkey, tt.tile.Quadkey())
+			got, err := ParseQuadkey(tt.quadkey)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.tile, got)
+		})
+	}
+
+	_, err := ParseQuadkey("0124")
+	assert.ErrorIs(t, err, ErrInvalidQuadkey)
+	_, err = ParseQuadkey("0000000000000000000000000000000")
+	assert.ErrorIs(t, err, ErrInvalidQuadkey)
+}
+
+func TestHashTiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		zoom    int
+		want    []Tile
+		wantErr error
+	}{
+		{
+			name:    "Invalid hash",
+			hash:    "9q8yy!",
+			wantErr: ErrInvalidHashFormat,
+		},
+		{
+			name:    "Invalid zoom",
+			hash:    "9q8yy",
+			zoom:    -1,
+			wantErr: ErrInvalidZoom,
+		},
+		{
+			name:    "Too many tiles",
+			hash:    "9",
+			zoom:    20,
+			wantErr: ErrCoverTooLarge,
+		},
+		{
+			name: "Single tile",
+			hash: "9q8yy",
+			zoom: 10,
+			want: []Tile{{X: 163, Y: 395, Z: 10}},
+		},
+		{
+			name: "Cell spanning several tiles",
+			hash: "9",
+			zoom: 3,
+			want: []Tile{{X: 1, Y: 2, Z: 3}, {X: 1, Y: 3, Z: 3}},
+		},
+		{
+			name: "Cell beyond the Mercator cutoff",
+			hash: "zzz",
+			zoom: 5,
+			want: []Tile{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HashTiles(tt.hash, tt.zoom)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTileHashes(t *testing.T) {
+	_, err := TileHashes(Tile{X: 4, Y: 0, Z: 2}, City)
+	assert.ErrorIs(t, err, ErrInvalidTile)
+
+	_, err = TileHashes(Tile{Z: 31}, City)
+	assert.ErrorIs(t, err, ErrInvalidZoom)
+
+	got, err := TileHashes(Tile{X: 163, Y: 395, Z: 10}, City)
+	require.NoError(t, err)
+	assert.Contains(t, got, "9q8yy")
+
+	bbox := Tile{X: 163, Y: 395, Z: 10}.BBox()
+	for _, hash := range got {
+		_, _, cell := MustDecodeBBox(hash)
+		assert.True(t, cell.MinLatitude < bbox.MaxLatitude && cell.MaxLatitude > bbox.MinLatitude, hash)
+		assert.True(t, cell.MinLongitude < bbox.MaxLongitude && cell.MaxLongitude > bbox.MinLongitude, hash)
+	}
+}
+
+func TestZoomPrecision(t *testing.T) {
+	tests := []struct {
+		zoom    int
+		want    Precision
+		wantErr error
+	}{
+		{zoom: -1, wantErr: ErrInvalidZoom},
+		{zoom: 31, wantErr: ErrInvalidZoom},
+		{zoom: 0, want: Global},
+		{zoom: 4, want: Global},
+		{zoom: 5, want: Country},
+		{zoom: 10, want: Region},
+		{zoom: 15, want: Street},
+		{zoom: 30, want: SubPoint},
+	}
+
+	for _, tt := range tests {
+		got, err := ZoomPrecision(tt.zoom)
+		assert.ErrorIs(t, err, tt.wantErr)
+		assert.Equal(t, tt.want, got, "ZoomPrecision(%d)", tt.zoom)
+	}
+}
@@ -0,0 +1,282 @@
+package geohash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// trajectoryVersion is the version of the binary serialization of a Trajectory.
+const trajectoryVersion = 1
+
+// minTrajectoryTime and maxTrajectoryTime bound the instants whose UnixNano fits in an int64.
+var (
+	minTrajectoryTime = time.Unix(0, math.MinInt64)
+	maxTrajectoryTime = time.Unix(0, math.MaxInt64)
+)
+
+var (
+	// ErrInvalidTrack is returned when the points of a track are not in chronological order.
+	ErrInvalidTrack = errors.New("invalid track")
+
+	// ErrInvalidTrajectory is returned when a trajectory or its binary serialization is malformed.
+	ErrInvalidTrajectory = errors.New("invalid trajectory")
+
+	// ErrInvalidTolerance is returned when a simplification tolerance is negative or not a finite number.
+	ErrInvalidTolerance = errors.New("invalid tolerance")
+)
+
+type (
+	// TrackPoint is a position recorded at an instant.
+	TrackPoint struct {
+		Latitude  float64
+		Longitude float64
+		Time      time.Time
+	}
+
+	// TrajectoryCell is a run of consecutive track points falling within the same cell.
+	TrajectoryCell struct {
+		// Hash identifies the cell.
+		Hash string
+		// Start and End are the times of the first and last points of the run.
+		Start time.Time
+		End   time.Time
+		// Count is the number of points in the run.
+		Count int
+	}
+
+	// Trajectory is a track compressed into runs of GeoHash cells at a fixed precision.
+	// It implements encoding.BinaryMarshaler and encoding.BinaryUnmarshaler.
+	Trajectory struct {
+		Precision Precision
+		Cells     []TrajectoryCell
+	}
+)
+
+// EncodeTrajectory simplifies a track with SimplifyTrack using the given tolerance in meters, zero keeping
+// every point, then encodes it as runs of consecutive points sharing a cell at the given precision.
+// Returns an error if the precision, tolerance, or any coordinates are invalid, or the points are not in
+// chronological order.
+func EncodeTrajectory(points []TrackPoint, precision Precision, tolerance float64) (Trajectory, error) {
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return Trajectory{}, err
+	}
+	if err := validateTrack(points); err != nil {
+		return Trajectory{}, err
+	}
+	if tolerance < 0 || !isFinite(tolerance) {
+		return Trajectory{}, ErrInvalidTolerance
+	}
+
+	t := Trajectory{Precision: precision}
+	for _, p := range SimplifyTrack(points, tolerance) {
+		hash, _ := Encode(p.Latitude, p.Longitude, precision)
+
+		if last := len(t.Cells) - 1; last >= 0 && t.Cells[last].Hash == hash {
+			t.Cells[last].End = p.Time
+			t.Cells[last].Count++
+			continue
+		}
+		t.Cells = append(t.Cells, TrajectoryCell{Hash: hash, Start: p.Time, End: p.Time, Count: 1})
+	}
+	return t, nil
+}
+
+// MustEncodeTrajectory encodes a track as runs of cells or panics if an error occurs.
+func MustEncodeTrajectory(points []TrackPoint, precision Precision, tolerance float64) Trajectory {
+	t, err := EncodeTrajectory(points, precision, tolerance)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Path decodes the trajectory into an approximate track through the centers of its cells: one point at the
+// start of each run, plus one at its end when the run lasts.
+func (t Trajectory) Path() []TrackPoint {
+	path := make([]TrackPoint, 0, len(t.Cells))
+	for _, c := range t.Cells {
+		lat, lng, err := Decode(c.Hash)
+		if err != nil {
+			continue
+		}
+
+		path = append(path, TrackPoint{Latitude: lat, Longitude: lng, Time: c.Start})
+		if c.End.After(c.Start) {
+			path = append(path, TrackPoint{Latitude: lat, Longitude: lng, Time: c.End})
+		}
+	}
+	return path
+}
+
+// MarshalBinary serializes the trajectory compactly: cells are stored as varint deltas of their bitsets and
+// timestamps, at nanosecond resolution within the years 1678 to 2262. Time zones are not preserved; decoded
+// times are in UTC.
+// Returns an error if the precision, a hash, a count, the chronological order of the cells, or a time outside
+// the supported range is invalid.
+func (t Trajectory) MarshalBinary() ([]byte, error) {
+	if err := checkPrecision(int(t.Precision), int(Global), int(SubPoint)); err != nil {
+		return nil, err
+	}
+
+	buf := []byte{trajectoryVersion, byte(t.Precision)}
+	buf = binary.AppendUvarint(buf, uint64(len(t.Cells)))
+
+	var prevBitset uint64
+	var prevTime int64
+	for i, c := range t.Cells {
+		if len(c.Hash) != int(t.Precision) || validateHash(c.Hash) != nil {
+			return nil, fmt.Errorf("%w: cell %d has hash %q", ErrInvalidTrajectory, i, c.Hash)
+		}
+		if c.Count < 1 || c.End.Before(c.Start) || i > 0 && c.Start.Before(t.Cells[i-1].End) {
+			return nil, fmt.Errorf("%w: cell %d is out of order", ErrInvalidTrajectory, i)
+		}
+		if c.Start.Before(minTrajectoryTime) || c.End.After(maxTrajectoryTime) {
+			return nil, fmt.Errorf("%w: cell %d is outside the supported time range", ErrInvalidTrajectory, i)
+		}
+
+		bitset, _, _ := decodeFromBase32(c.Hash)
+		start, end := c.Start.UnixNano(), c.End.UnixNano()
+
+		buf = binary.AppendVarint(buf, int64(bitset-prevBitset))
+		buf = binary.AppendVarint(buf, start-prevTime)
+		buf = binary.AppendUvarint(buf, uint64(end-start))
+		buf = binary.AppendUvarint(buf, uint64(c.Count))
+
+		prevBitset, prevTime = bitset, end
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary restores a trajectory serialized by MarshalBinary.
+// Returns an error if the data is malformed.
+func (t *Trajectory) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != trajectoryVersion {
+		return fmt.Errorf("%w: unsupported header", ErrInvalidTrajectory)
+	}
+	precision := Precision(data[1])
+	if err := checkPrecision(int(precision), int(Global), int(SubPoint)); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTrajectory, err)
+	}
+
+	r := bytes.NewReader(data[2:])
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return fmt.Errorf("%w: truncated data", ErrInvalidTrajectory)
+	}
+
+	cells := make([]TrajectoryCell, n)
+	var prevBitset uint64
+	var prevTime int64
+	for i := range cells {
+		bitsetDelta, errBitset := binary.ReadVarint(r)
+		startDelta, errStart := binary.ReadVarint(r)
+		duration, errDuration := binary.ReadUvarint(r)
+		count, errCount := binary.ReadUvarint(r)
+		if errors.Join(errBitset, errStart, errDuration, errCount) != nil {
+			return fmt.Errorf("%w: truncated data", ErrInvalidTrajectory)
+		}
+
+		bitset := prevBitset + uint64(bitsetDelta)
+		// The first start is absolute, so any int64 is a valid UnixNano; later ones must not wrap around
+		// or precede the end of the previous cell.
+		start := prevTime + startDelta
+		end := start + int64(duration)
+		if bitset >= uint64(1)<<(int(precision)*bitsPerChar) || count < 1 || count > math.MaxInt ||
+			i > 0 && (startDelta < 0 || start < prevTime) || duration > math.MaxInt64 || end < start {
+			return fmt.Errorf("%w: cell %d is malformed", ErrInvalidTrajectory, i)
+		}
+
+		cells[i] = TrajectoryCell{
+			Hash:  encodeToBase32(bitset, precision),
+			Start: time.Unix(0, start).UTC(),
+			End:   time.Unix(0, end).UTC(),
+			Count: int(count),
+		}
+		prevBitset, prevTime = bitset, end
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("%w: trailing data", ErrInvalidTrajectory)
+	}
+
+	t.Precision = precision
+	t.Cells = cells
+	return nil
+}
+
+// SimplifyTrack returns the points kept by the Douglas–Peucker algorithm: the endpoints, and recursively
+// the point farthest from the segment joining the kept ones while it lies more than tolerance meters away.
+// Distances are measured in a local equirectangular projection around each segment, suited to the short
+// segments of GPS tracks. A non-positive tolerance keeps every point.
+func SimplifyTrack(points []TrackPoint, tolerance float64) []TrackPoint {
+	if tolerance <= 0 || len(points) < 3 {
+		return append([]TrackPoint(nil), points...)
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		first, last := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		farthest, distance := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(points[i], points[first], points[last]); d > distance {
+				farthest, distance = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+
+		keep[farthest] = true
+		stack = append(stack, [2]int{first, farthest}, [2]int{farthest, last})
+	}
+
+	simplified := make([]TrackPoint, 0, len(points))
+	for i, p := range points {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+	return simplified
+}
+
+// segmentDistance returns the distance in meters from p to the segment from a to b, projecting the points
+// onto a plane tangent at a.
+func segmentDistance(p, a, b TrackPoint) float64 {
+	scale := math.Cos(degToRad(a.Latitude))
+	project := func(q TrackPoint) (x, y float64) {
+		x = degToRad(wrapLongitude(q.Longitude-a.Longitude)) * scale * earthRadius
+		y = degToRad(q.Latitude-a.Latitude) * earthRadius
+		return x, y
+	}
+
+	px, py := project(p)
+	bx, by := project(b)
+
+	t := 0.0
+	if length := bx*bx + by*by; length > 0 {
+		t = math.Max(0, math.Min(1, (px*bx+py*by)/length))
+	}
+	return math.Hypot(px-t*bx, py-t*by)
+}
+
+// validateTrack reports whether every point of the track has valid coordinates and the points are in
+// chronological order.
+func validateTrack(points []TrackPoint) error {
+	for i, p := range points {
+		if err := checkCoordinates(p.Latitude, p.Longitude); err != nil {
+			return err
+		}
+		if i > 0 && p.Time.Before(points[i-1].Time) {
+			return fmt.Errorf("%w: point %d is earlier than point %d", ErrInvalidTrack, i, i-1)
+		}
+	}
+	return nil
+}
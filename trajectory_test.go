@@ -0,0 +1,308 @@
+package geohash
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Trajectory{}
+	_ encoding.BinaryUnmarshaler = (*Trajectory)(nil)
+)
+
+var trackStart = time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+// straightTrack returns points every 10 seconds along a meridian, about 11 m apart, with a detour
+// of about 500 m to the east in the middle.
+func straightTrack() []TrackPoint {
+	var points []TrackPoint
+	for i := 0; i <= 100; i++ {
+		lng := -122.4194
+		if i == 50 {
+			lng += 0.0057
+		}
+		points = append(points, TrackPoint{
+			Latitude:  37.7749 + float64(i)*0.0001,
+			Longitude: lng,
+			Time:      trackStart.Add(time.Duration(i) * 10 * time.Second),
+		})
+	}
+	return points
+}
+
+func TestSimplifyTrack(t *testing.T) {
+	points := straightTrack()
+
+	simplified := SimplifyTrack(points, 5)
+	require.Len(t, simplified, 5)
+	assert.Equal(t, []TrackPoint{points[0], points[49], points[50], points[51], points[100]}, simplified)
+
+	simplified = SimplifyTrack(points, 1000)
+	assert.Equal(t, []TrackPoint{points[0], points[100]}, simplified)
+
+	assert.Equal(t, points, SimplifyTrack(points, 0))
+	assert.Equal(t, points[:2], SimplifyTrack(points[:2], 5))
+	assert.Empty(t, SimplifyTrack(nil, 5))
+
+	// A straight track across the antimeridian keeps its endpoints only.
+	across := []TrackPoint{
+		{Latitude: -16.8, Longitude: 179.998},
+		{Latitude: -16.8, Longitude: 179.999},
+		{Latitude: -16.8, Longitude: -179.999},
+		{Latitude: -16.8, Longitude: -179.998},
+	}
+	assert.Equal(t, []TrackPoint{across[0], across[3]}, SimplifyTrack(across, 1))
+}
+
+func TestSegmentDistance(t *testing.T) {
+	a := TrackPoint{Latitude: 45, Longitude: 7}
+	b := TrackPoint{Latitude: 45.1, Longitude: 7}
+
+	east := TrackPoint{Latitude: 45.05, Longitude: 7 + radToDeg(1000/earthRadius)/math.Cos(degToRad(45))}
+	assert.InDelta(t, 1000, segmentDistance(east, a, b), 1)
+
+	beyond := TrackPoint{Latitude: 45.1 + radToDeg(500/earthRadius), Longitude: 7}
+	assert.InDelta(t, 500, segmentDistance(beyond, a, b), 1)
+
+	assert.InDelta(t, Distance(45, 7, 45.05, 7), segmentDistance(TrackPoint{Latitude: 45.05, Longitude: 7}, a, a), 1e-6)
+}
+
+func TestEncodeTrajectory(t *testing.T) {
+	points := straightTrack()
+
+	got, err := EncodeTrajectory(points, Street, 0)
+	require.NoError(t, err)
+	assert.Equal(t, Street, got.Precision)
+
+	count := 0
+	for i, c := range got.Cells {
+		assert.Len(t, c.Hash, int(Street))
+		assert.False(t, c.End.Before(c.Start))
+		if i > 0 {
+			assert.NotEqual(t, got.Cells[i-1].Hash, c.Hash)
+		}
+		count += c.Count
+	}
+	assert.Equal(t, len(points), count)
+	assert.Equal(t, TrajectoryCell{Hash: "9q8yyk", Start: points[0].Time, End: points[15].Time, Count: 16}, got.Cells[0])
+	assert.NotEqual(t, "9q8yyk", MustEncode(points[16].Latitude, points[16].Longitude, Street))
+
+	simplified, err := EncodeTrajectory(points, Street, 5)
+	require.NoError(t, err)
+	count = 0
+	for _, c := range simplified.Cells {
+		count += c.Count
+	}
+	assert.Equal(t, 5, count)
+}
+
+func TestEncodeTrajectoryErrors(t *testing.T) {
+	points := straightTrack()
+
+	_, err := EncodeTrajectory(points, 0, 0)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	_, err = EncodeTrajectory(points, Street, -1)
+	assert.ErrorIs(t, err, ErrInvalidTolerance)
+
+	_, err = EncodeTrajectory(points, Street, math.NaN())
+	assert.ErrorIs(t, err, ErrInvalidTolerance)
+
+	unordered := append([]TrackPoint(nil), points...)
+	unordered[3].Time = trackStart
+	_, err = EncodeTrajectory(unordered, Street, 0)
+	assert.ErrorIs(t, err, ErrInvalidTrack)
+	assert.EqualError(t, err, "invalid track: point 3 is earlier than point 2")
+
+	invalid := append([]TrackPoint(nil), points...)
+	invalid[7].Latitude = 91
+	_, err = EncodeTrajectory(invalid, Street, 0)
+	assert.ErrorIs(t, err, ErrLatitudeOutOfRange)
+
+	assert.Panics(t, func() { MustEncodeTrajectory(invalid, Street, 0) })
+
+	empty, err := EncodeTrajectory(nil, Street, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, empty.Cells)
+}
+
+func TestTrajectoryPath(t *testing.T) {
+	trajectory := MustEncodeTrajectory(straightTrack(), Building, 0)
+	path := trajectory.Path()
+
+	i := 0
+	for _, c := range trajectory.Cells {
+		lat, lng := MustDecode(c.Hash)
+		assert.Equal(t, TrackPoint{Latitude: lat, Longitude: lng, Time: c.Start}, path[i])
+		i++
+		if c.End.After(c.Start) {
+			assert.Equal(t, TrackPoint{Latitude: lat, Longitude: lng, Time: c.End}, path[i])
+			i++
+		}
+	}
+	assert.Len(t, path, i)
+
+	for _, p := range path {
+		assert.InDelta(t, -122.4194, p.Longitude, 0.01)
+	}
+}
+
+func TestTrajectoryBinary(t *testing.T) {
+	points := straightTrack()
+	for i := range points {
+		points[i].Time = points[i].Time.Add(time.Duration(i) * time.Millisecond)
+	}
+	trajectory := MustEncodeTrajectory(points, Block, 0)
+
+	data, err := trajectory.MarshalBinary()
+	require.NoError(t, err)
+	// A fixed-width layout would store the hash, two 64-bit timestamps, and a 32-bit count per cell.
+	assert.Less(t, len(data), len(trajectory.Cells)*(int(Block)+8+8+4)/2)
+
+	var decoded Trajectory
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, trajectory, decoded)
+
+	// Times before 1970 and in other time zones round-trip as the same instants in UTC.
+	zone := time.FixedZone("PDT", -7*3600)
+	old := Trajectory{Precision: Global, Cells: []TrajectoryCell{
+		{Hash: "9", Start: time.Date(1969, 7, 20, 13, 17, 0, 0, zone), End: time.Date(1969, 7, 20, 13, 17, 0, 0, zone), Count: 1},
+		{Hash: "0", Start: time.Date(1969, 7, 21, 0, 0, 0, 0, zone), End: time.Date(1969, 7, 24, 0, 0, 0, 0, zone), Count: 3},
+	}}
+	data, err = old.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Len(t, decoded.Cells, 2)
+	for i, c := range decoded.Cells {
+		assert.True(t, c.Start.Equal(old.Cells[i].Start))
+		assert.True(t, c.End.Equal(old.Cells[i].End))
+		assert.Equal(t, time.UTC, c.Start.Location())
+		assert.Equal(t, old.Cells[i].Hash, c.Hash)
+		assert.Equal(t, old.Cells[i].Count, c.Count)
+	}
+
+	data, err = Trajectory{Precision: Street}.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, Street, decoded.Precision)
+	assert.Empty(t, decoded.Cells)
+}
+
+func TestTrajectoryMarshalBinaryErrors(t *testing.T) {
+	cell := TrajectoryCell{Hash: "9q8yy", Start: trackStart, End: trackStart.Add(time.Minute), Count: 2}
+
+	tests := []struct {
+		name       string
+		trajectory Trajectory
+		wantErr    error
+	}{
+		{
+			name:       "Invalid precision",
+			trajectory: Trajectory{Precision: 13},
+			wantErr:    ErrPrecisionOutOfRange,
+		},
+		{
+			name:       "Hash of another precision",
+			trajectory: Trajectory{Precision: Street, Cells: []TrajectoryCell{cell}},
+			wantErr:    ErrInvalidTrajectory,
+		},
+		{
+			name: "Invalid hash",
+			trajectory: Trajectory{Precision: City, Cells: []TrajectoryCell{
+				{Hash: "9q8ya", Start: trackStart, End: trackStart, Count: 1},
+			}},
+			wantErr: ErrInvalidTrajectory,
+		},
+		{
+			name: "Empty run",
+			trajectory: Trajectory{Precision: City, Cells: []TrajectoryCell{
+				{Hash: "9q8yy", Start: trackStart, End: trackStart},
+			}},
+			wantErr: ErrInvalidTrajectory,
+		},
+		{
+			name: "Run ending before it starts",
+			trajectory: Trajectory{Precision: City, Cells: []TrajectoryCell{
+				{Hash: "9q8yy", Start: trackStart, End: trackStart.Add(-time.Second), Count: 1},
+			}},
+			wantErr: ErrInvalidTrajectory,
+		},
+		{
+			name: "Runs out of order",
+			trajectory: Trajectory{Precision: City, Cells: []TrajectoryCell{
+				cell,
+				{Hash: "9q8yz", Start: trackStart, End: trackStart, Count: 1},
+			}},
+			wantErr: ErrInvalidTrajectory,
+		},
+		{
+			name: "Time beyond the supported range",
+			trajectory: Trajectory{Precision: City, Cells: []TrajectoryCell{
+				{Hash: "9q8yy", Start: trackStart, End: trackStart.AddDate(500, 0, 0), Count: 1},
+			}},
+			wantErr: ErrInvalidTrajectory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.trajectory.MarshalBinary()
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTrajectoryUnmarshalBinaryErrors(t *testing.T) {
+	valid, err := MustEncodeTrajectory(straightTrack(), Block, 0).MarshalBinary()
+	require.NoError(t, err)
+
+	// cells serializes runs of one point in cell 2 at precision 1 with the given start deltas and durations.
+	cells := func(runs ...[2]uint64) []byte {
+		data := binary.AppendUvarint([]byte{trajectoryVersion, 1}, uint64(len(runs)))
+		for i, r := range runs {
+			bitsetDelta := int64(0)
+			if i == 0 {
+				bitsetDelta = 2
+			}
+			data = binary.AppendVarint(data, bitsetDelta)
+			data = binary.AppendVarint(data, int64(r[0]))
+			data = binary.AppendUvarint(data, r[1])
+			data = binary.AppendUvarint(data, 1)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "Empty", data: nil},
+		{name: "Unknown version", data: []byte{2, 5, 0}},
+		{name: "Invalid precision", data: []byte{1, 13, 0}},
+		{name: "Missing cell count", data: []byte{1, 5}},
+		{name: "Too many cells", data: []byte{1, 5, 100, 0}},
+		{name: "Truncated", data: valid[:len(valid)-1]},
+		{name: "Trailing data", data: append(append([]byte(nil), valid...), 0)},
+		{name: "Bitset beyond precision", data: []byte{1, 1, 1, 64, 0, 0, 1}},
+		{name: "Empty run", data: []byte{1, 1, 1, 2, 0, 0, 0}},
+		{name: "Runs out of order", data: []byte{1, 1, 2, 2, 20, 0, 1, 2, 1, 0, 1}},
+		{name: "Start overflowing", data: cells([2]uint64{math.MaxInt64 - 5, 0}, [2]uint64{10, 0})},
+		{name: "Start wrapping backwards", data: cells([2]uint64{1 << 63, 0}, [2]uint64{1 << 63, 0})},
+		{name: "Duration overflowing", data: cells([2]uint64{0, 1 << 63})},
+		{name: "End overflowing", data: cells([2]uint64{math.MaxInt64 - 5, 10})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var trajectory Trajectory
+			assert.ErrorIs(t, trajectory.UnmarshalBinary(tt.data), ErrInvalidTrajectory)
+			assert.Equal(t, Trajectory{}, trajectory)
+		})
+	}
+}
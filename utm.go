@@ -0,0 +1,361 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// UTMMinLatitude and UTMMaxLatitude bound the latitudes covered by UTM; the polar regions use UPS instead.
+	UTMMinLatitude float64 = -80
+	UTMMaxLatitude float64 = 84
+
+	// wgs84SemiMajorAxis and wgs84Flattening define the WGS84 ellipsoid.
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+
+	utmScaleFactor    = 0.9996
+	utmFalseEasting   = 500000.0
+	utmFalseNorthing  = 10000000.0
+	utmZones          = 60
+	utmBandHeight     = 8
+	mgrsSquareSize    = 100000.0
+	mgrsMaxDigits     = 5
+	mgrsNorthingCycle = 2000000.0
+)
+
+var (
+	// ErrInvalidUTM is returned when a UTM position has an invalid zone, band, easting or northing.
+	ErrInvalidUTM = errors.New("invalid utm position")
+
+	// ErrInvalidMGRS is returned when a string is not a valid MGRS reference.
+	ErrInvalidMGRS = errors.New("invalid mgrs reference")
+)
+
+const (
+	// utmBands are the latitude bands from 80°S, each 8° high except X which spans 72°N to 84°N.
+	utmBands = "CDEFGHJKLMNPQRSTUVWX"
+
+	// mgrsRows are the 100 km row letters, repeating every 2000 km of northing.
+	mgrsRows = "ABCDEFGHJKLMNPQRSTUV"
+)
+
+// mgrsColumns are the 100 km column letters of each of the three repeating zone sets.
+var mgrsColumns = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+
+// utmSeries holds the Krüger series coefficients of the WGS84 ellipsoid.
+var utmSeries = newUTMSeries()
+
+// UTM is a Universal Transverse Mercator position on the WGS84 ellipsoid.
+type UTM struct {
+	// Zone is the longitude zone, from 1 to 60.
+	Zone int
+	// Band is the latitude band letter, from C to X; bands N and above are in the northern hemisphere.
+	Band byte
+	// Easting and Northing are in meters; southern northings include the 10000 km false northing.
+	Easting  float64
+	Northing float64
+}
+
+// String formats the position as "31U 448252 5411933", rounded to the meter.
+func (u UTM) String() string {
+	return fmt.Sprintf("%d%c %.0f %.0f", u.Zone, u.Band, u.Easting, u.Northing)
+}
+
+// EncodeUTM returns the UTM position of the given coordinates, honoring the Norway and Svalbard zone exceptions.
+// Returns an error if the coordinates are out of range, including latitudes outside the UTM limits.
+func EncodeUTM(latitude, longitude float64) (UTM, error) {
+	if err := checkLatitude(latitude, UTMMinLatitude, UTMMaxLatitude); err != nil {
+		return UTM{}, err
+	}
+	if err := checkLongitude(longitude); err != nil {
+		return UTM{}, err
+	}
+
+	zone := utmZone(latitude, longitude)
+	band := utmBands[min(int(math.Floor((latitude-UTMMinLatitude)/utmBandHeight)), len(utmBands)-1)]
+
+	easting, northing := utmProject(degToRad(latitude), degToRad(longitude-utmCentralMeridian(zone)))
+	if latitude < 0 {
+		northing += utmFalseNorthing
+	}
+	return UTM{Zone: zone, Band: band, Easting: easting, Northing: northing}, nil
+}
+
+// MustEncodeUTM returns the UTM position of the given coordinates or panics if an error occurs.
+func MustEncodeUTM(latitude, longitude float64) UTM {
+	u, err := EncodeUTM(latitude, longitude)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// DecodeUTM returns the coordinates of a UTM position.
+// Returns an error if the zone, band, easting or northing is invalid.
+func DecodeUTM(u UTM) (latitude, longitude float64, err error) {
+	if u.Zone < 1 || u.Zone > utmZones || strings.IndexByte(utmBands, u.Band) < 0 {
+		return 0, 0, ErrInvalidUTM
+	}
+	if u.Easting < 0 || u.Easting > 2*utmFalseEasting || u.Northing < 0 || u.Northing > utmFalseNorthing {
+		return 0, 0, ErrInvalidUTM
+	}
+
+	northing := u.Northing
+	if u.Band < 'N' {
+		northing -= utmFalseNorthing
+	}
+	phi, lambda := utmUnproject(u.Easting, northing)
+	return radToDeg(phi), wrapLongitude(radToDeg(lambda) + utmCentralMeridian(u.Zone)), nil
+}
+
+// MustDecodeUTM returns the coordinates of a UTM position or panics if an error occurs.
+func MustDecodeUTM(u UTM) (latitude, longitude float64) {
+	lat, lng, err := DecodeUTM(u)
+	if err != nil {
+		panic(err)
+	}
+	return lat, lng
+}
+
+// EncodeMGRS returns the MGRS reference of the given coordinates in compact form, such as "31UDQ4825111932".
+// The number of digits per axis ranges from 0 (100 km square) to 5 (1 m); coordinates are truncated, not rounded.
+// Returns an error if the coordinates are out of range or the number of digits is invalid.
+func EncodeMGRS(latitude, longitude float64, digits int) (string, error) {
+	if digits < 0 || digits > mgrsMaxDigits {
+		return "", ErrInvalidCodeLength
+	}
+	u, err := EncodeUTM(latitude, longitude)
+	if err != nil {
+		return "", err
+	}
+
+	column := int(math.Floor(u.Easting / mgrsSquareSize))
+	row := int(math.Floor(math.Mod(u.Northing, mgrsNorthingCycle) / mgrsSquareSize))
+	set := (u.Zone - 1) % len(mgrsColumns)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d%c", u.Zone, u.Band)
+	sb.WriteByte(mgrsColumns[set][(column-1+len(mgrsColumns[set]))%len(mgrsColumns[set])])
+	sb.WriteByte(mgrsRows[(row+mgrsRowOffset(u.Zone))%len(mgrsRows)])
+
+	if digits > 0 {
+		unit := math.Pow10(mgrsMaxDigits - digits)
+		fmt.Fprintf(&sb, "%0*d%0*d",
+			digits, int(math.Mod(u.Easting, mgrsSquareSize)/unit),
+			digits, int(math.Mod(u.Northing, mgrsSquareSize)/unit))
+	}
+	return sb.String(), nil
+}
+
+// MustEncodeMGRS returns the MGRS reference of the given coordinates or panics if an error occurs.
+func MustEncodeMGRS(latitude, longitude float64, digits int) string {
+	mgrs, err := EncodeMGRS(latitude, longitude, digits)
+	if err != nil {
+		panic(err)
+	}
+	return mgrs
+}
+
+// DecodeMGRS returns the UTM position of the south-west corner of an MGRS square and its size in meters.
+// Spaces and lower-case letters are accepted, as in "31U DQ 48251 11932".
+// Returns an error if the reference is invalid.
+func DecodeMGRS(mgrs string) (corner UTM, size float64, err error) {
+	mgrs = strings.ToUpper(strings.ReplaceAll(mgrs, " ", ""))
+
+	zoneDigits := 0
+	for zoneDigits < len(mgrs) && zoneDigits < 2 && mgrs[zoneDigits] >= '0' && mgrs[zoneDigits] <= '9' {
+		zoneDigits++
+	}
+	if zoneDigits == 0 || len(mgrs) < zoneDigits+3 {
+		return UTM{}, 0, ErrInvalidMGRS
+	}
+	zone, _ := strconv.Atoi(mgrs[:zoneDigits])
+	band := mgrs[zoneDigits]
+	if zone < 1 || zone > utmZones || strings.IndexByte(utmBands, band) < 0 {
+		return UTM{}, 0, ErrInvalidMGRS
+	}
+
+	set := mgrsColumns[(zone-1)%len(mgrsColumns)]
+	column := strings.IndexByte(set, mgrs[zoneDigits+1])
+	row := strings.IndexByte(mgrsRows, mgrs[zoneDigits+2])
+	if column < 0 || row < 0 {
+		return UTM{}, 0, ErrInvalidMGRS
+	}
+	row = (row - mgrsRowOffset(zone) + len(mgrsRows)) % len(mgrsRows)
+
+	numbers := mgrs[zoneDigits+3:]
+	if len(numbers)%2 == 1 || len(numbers) > 2*mgrsMaxDigits {
+		return UTM{}, 0, ErrInvalidMGRS
+	}
+	digits := len(numbers) / 2
+	size = math.Pow10(mgrsMaxDigits - digits)
+	var easting, northing float64
+	if digits > 0 {
+		e, errE := strconv.ParseUint(numbers[:digits], 10, 32)
+		n, errN := strconv.ParseUint(numbers[digits:], 10, 32)
+		if errE != nil || errN != nil {
+			return UTM{}, 0, ErrInvalidMGRS
+		}
+		easting, northing = float64(e)*size, float64(n)*size
+	}
+
+	easting += float64(column+1) * mgrsSquareSize
+	northing += float64(row) * mgrsSquareSize
+
+	// The row letters repeat every 2000 km; pick the cycle reaching the band's southern edge.
+	bandNorthing := mgrsBandNorthing(band)
+	for northing < bandNorthing {
+		northing += mgrsNorthingCycle
+	}
+	return UTM{Zone: zone, Band: band, Easting: easting, Northing: northing}, size, nil
+}
+
+// MGRSHash returns the GeoHash at the given precision of the center of an MGRS square.
+// Returns an error if the reference or precision is invalid.
+func MGRSHash(mgrs string, precision Precision) (string, error) {
+	corner, size, err := DecodeMGRS(mgrs)
+	if err != nil {
+		return "", err
+	}
+	corner.Easting += size / 2
+	corner.Northing += size / 2
+	lat, lng, err := DecodeUTM(corner)
+	if err != nil {
+		return "", err
+	}
+	return Encode(lat, lng, precision)
+}
+
+// HashMGRS returns the MGRS reference with the given number of digits of the center of a GeoHash cell.
+// Returns an error if the hash or number of digits is invalid, or if the cell center lies outside UTM latitudes.
+func HashMGRS(hash string, digits int) (string, error) {
+	lat, lng, err := Decode(hash)
+	if err != nil {
+		return "", err
+	}
+	return EncodeMGRS(lat, lng, digits)
+}
+
+// utmZone returns the UTM zone of the given coordinates, including the Norway and Svalbard exceptions.
+func utmZone(latitude, longitude float64) int {
+	zone := min(int(math.Floor((longitude-minLongitude)/6))+1, utmZones)
+
+	switch {
+	case latitude >= 56 && latitude < 64 && longitude >= 3 && longitude < 12:
+		// South-western Norway is widened into zone 32.
+		zone = 32
+	case latitude >= 72 && longitude >= 0 && longitude < 42:
+		// Svalbard uses the odd zones 31, 33, 35 and 37 only.
+		switch {
+		case longitude < 9:
+			zone = 31
+		case longitude < 21:
+			zone = 33
+		case longitude < 33:
+			zone = 35
+		default:
+			zone = 37
+		}
+	}
+	return zone
+}
+
+// utmCentralMeridian returns the central meridian of a zone in degrees.
+func utmCentralMeridian(zone int) float64 {
+	return float64(zone-1)*6 + minLongitude + 3
+}
+
+// mgrsRowOffset returns the row letter offset of a zone; even zones start their rows at F.
+func mgrsRowOffset(zone int) int {
+	if zone%2 == 0 {
+		return 5
+	}
+	return 0
+}
+
+// mgrsBandNorthing returns a northing, rounded down to 100 km, south of every point of the band.
+func mgrsBandNorthing(band byte) float64 {
+	latitude := UTMMinLatitude + float64(strings.IndexByte(utmBands, band)*utmBandHeight)
+	_, northing := utmProject(degToRad(latitude), 0)
+	if latitude < 0 {
+		// Away from the central meridian southern northings shrink, so allow one extra square of slack.
+		northing += utmFalseNorthing - mgrsSquareSize
+	}
+	return math.Floor(northing/mgrsSquareSize) * mgrsSquareSize
+}
+
+// krugerSeries holds the coefficients of the Krüger transverse Mercator series to the third order.
+type krugerSeries struct {
+	a                  float64
+	n                  float64
+	alpha, beta, delta [3]float64
+}
+
+// newUTMSeries computes the Krüger series coefficients of the WGS84 ellipsoid.
+func newUTMSeries() krugerSeries {
+	n := wgs84Flattening / (2 - wgs84Flattening)
+	n2, n3 := n*n, n*n*n
+	return krugerSeries{
+		a: wgs84SemiMajorAxis / (1 + n) * (1 + n2/4 + n2*n2/64),
+		n: n,
+		alpha: [3]float64{
+			n/2 - 2*n2/3 + 5*n3/16,
+			13*n2/48 - 3*n3/5,
+			61 * n3 / 240,
+		},
+		beta: [3]float64{
+			n/2 - 2*n2/3 + 37*n3/96,
+			n2/48 + n3/15,
+			17 * n3 / 480,
+		},
+		delta: [3]float64{
+			2*n - 2*n2/3 - 2*n3,
+			7*n2/3 - 8*n3/5,
+			56 * n3 / 15,
+		},
+	}
+}
+
+// utmProject returns the easting and the northing without false northing of a latitude and a longitude
+// relative to the central meridian, both in radians.
+func utmProject(phi, lambda float64) (easting, northing float64) {
+	s := utmSeries
+	c := 2 * math.Sqrt(s.n) / (1 + s.n)
+	t := math.Sinh(math.Atanh(math.Sin(phi)) - c*math.Atanh(c*math.Sin(phi)))
+	xi := math.Atan2(t, math.Cos(lambda))
+	eta := math.Atanh(math.Sin(lambda) / math.Sqrt(1+t*t))
+
+	x, y := eta, xi
+	for j, alpha := range s.alpha {
+		k := float64(2 * (j + 1))
+		x += alpha * math.Cos(k*xi) * math.Sinh(k*eta)
+		y += alpha * math.Sin(k*xi) * math.Cosh(k*eta)
+	}
+	return utmFalseEasting + utmScaleFactor*s.a*x, utmScaleFactor * s.a * y
+}
+
+// utmUnproject returns the latitude and the longitude relative to the central meridian, both in radians,
+// of an easting and a northing without false northing.
+func utmUnproject(easting, northing float64) (phi, lambda float64) {
+	s := utmSeries
+	xi := northing / (utmScaleFactor * s.a)
+	eta := (easting - utmFalseEasting) / (utmScaleFactor * s.a)
+
+	xiP, etaP := xi, eta
+	for j, beta := range s.beta {
+		k := float64(2 * (j + 1))
+		xiP -= beta * math.Sin(k*xi) * math.Cosh(k*eta)
+		etaP -= beta * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+
+	chi := math.Asin(math.Sin(xiP) / math.Cosh(etaP))
+	phi = chi
+	for j, delta := range s.delta {
+		phi += delta * math.Sin(float64(2*(j+1))*chi)
+	}
+	return phi, math.Atan2(math.Sinh(etaP), math.Cos(xiP))
+}
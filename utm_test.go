@@ -0,0 +1,201 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeUTM(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		want      string
+		wantErr   error
+	}{
+		{
+			name:     "Beyond the northern limit",
+			latitude: 84.1,
+			wantErr:  ErrLatitudeOutOfRange,
+		},
+		{
+			name:     "Beyond the southern limit",
+			latitude: -80.1,
+			wantErr:  ErrLatitudeOutOfRange,
+		},
+		{
+			name:      "Longitude out of range",
+			longitude: 181,
+			wantErr:   ErrLongitudeOutOfRange,
+		},
+		{
+			name: "Origin",
+			want: "31N 166021 0",
+		},
+		{
+			name:      "Eiffel Tower",
+			latitude:  48.8582,
+			longitude: 2.2945,
+			want:      "31U 448252 5411933",
+		},
+		{
+			name:      "Southern hemisphere",
+			latitude:  -33.8568,
+			longitude: 151.2153,
+			want:      "56H 334901 6252289",
+		},
+		{
+			name:     "Southern limit",
+			latitude: -80,
+			want:     "31C 441868 1116915",
+		},
+		{
+			name:      "Northern limit in the last zone",
+			latitude:  84,
+			longitude: 179.5,
+			want:      "60X 529166 9328727",
+		},
+		{
+			name:      "West of the Norway exception",
+			latitude:  60,
+			longitude: 2.99,
+			want:      "31V 499442 6651411",
+		},
+		{
+			name:      "Norway exception",
+			latitude:  56,
+			longitude: 3,
+			want:      "32V 126050 6222336",
+		},
+		{
+			name:      "Svalbard zone 31",
+			latitude:  78,
+			longitude: 8,
+			want:      "31X 615915 8663320",
+		},
+		{
+			name:      "Svalbard zone 33",
+			latitude:  78,
+			longitude: 10,
+			want:      "33X 384085 8663320",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeUTM(tt.latitude, tt.longitude)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got.String())
+
+			lat, lng, err := DecodeUTM(got)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.latitude, lat, tolerance)
+			assert.InDelta(t, tt.longitude, lng, tolerance)
+		})
+	}
+}
+
+func TestDecodeUTM(t *testing.T) {
+	tests := []struct {
+		name string
+		utm  UTM
+	}{
+		{name: "Zone too low", utm: UTM{Zone: 0, Band: 'N', Easting: 500000}},
+		{name: "Zone too high", utm: UTM{Zone: 61, Band: 'N', Easting: 500000}},
+		{name: "Invalid band", utm: UTM{Zone: 31, Band: 'I', Easting: 500000}},
+		{name: "Negative easting", utm: UTM{Zone: 31, Band: 'N', Easting: -1}},
+		{name: "Northing too large", utm: UTM{Zone: 31, Band: 'N', Easting: 500000, Northing: 10000001}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := DecodeUTM(tt.utm)
+			assert.ErrorIs(t, err, ErrInvalidUTM)
+		})
+	}
+}
+
+func TestEncodeMGRS(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		digits    int
+		want      string
+		wantErr   error
+	}{
+		{name: "Invalid digits", digits: 6, wantErr: ErrInvalidCodeLength},
+		{name: "Latitude out of range", latitude: 85, digits: 5, wantErr: ErrLatitudeOutOfRange},
+		{name: "100 km square", latitude: 48.8582, longitude: 2.2945, digits: 0, want: "31UDQ"},
+		{name: "10 km square", latitude: 48.8582, longitude: 2.2945, digits: 1, want: "31UDQ41"},
+		{name: "1 m square", latitude: 48.8582, longitude: 2.2945, digits: 5, want: "31UDQ4825111932"},
+		{name: "Even zone", latitude: -33.8568, longitude: 151.2153, digits: 3, want: "56HLH349522"},
+		{name: "Southern limit", latitude: -79.9, longitude: -170, digits: 5, want: "2CNS1957629407"},
+		{name: "Svalbard", latitude: 83.9, longitude: 10, digits: 5, want: "33XVP4075419502"},
+		{name: "South of the equator", latitude: -0.0001, longitude: 3, digits: 5, want: "31MEV0000099988"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeMGRS(tt.latitude, tt.longitude, tt.digits)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeMGRS(t *testing.T) {
+	tests := []struct {
+		mgrs     string
+		want     UTM
+		wantSize float64
+		wantErr  error
+	}{
+		{mgrs: "31U DQ 48251 11932", want: UTM{Zone: 31, Band: 'U', Easting: 448251, Northing: 5411932}, wantSize: 1},
+		{mgrs: "31udq41", want: UTM{Zone: 31, Band: 'U', Easting: 440000, Northing: 5410000}, wantSize: 10000},
+		{mgrs: "56HLH349522", want: UTM{Zone: 56, Band: 'H', Easting: 334900, Northing: 6252200}, wantSize: 100},
+		{mgrs: "2CNS1957629407", want: UTM{Zone: 2, Band: 'C', Easting: 519576, Northing: 1129407}, wantSize: 1},
+		{mgrs: "31MEV0000099988", want: UTM{Zone: 31, Band: 'M', Easting: 500000, Northing: 9999988}, wantSize: 1},
+		{mgrs: "", wantErr: ErrInvalidMGRS},
+		{mgrs: "31U", wantErr: ErrInvalidMGRS},
+		{mgrs: "61UDQ", wantErr: ErrInvalidMGRS},
+		{mgrs: "31IDQ", wantErr: ErrInvalidMGRS},
+		{mgrs: "31UJQ", wantErr: ErrInvalidMGRS},
+		{mgrs: "31UDW", wantErr: ErrInvalidMGRS},
+		{mgrs: "31UDQ482", wantErr: ErrInvalidMGRS},
+		{mgrs: "31UDQ4825x11932", wantErr: ErrInvalidMGRS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mgrs, func(t *testing.T) {
+			got, size, err := DecodeMGRS(tt.mgrs)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantSize, size)
+		})
+	}
+}
+
+func TestMGRSHash(t *testing.T) {
+	got, err := MGRSHash("31U DQ 48251 11932", Street)
+	require.NoError(t, err)
+	assert.Equal(t, "u09tun", got)
+
+	_, err = MGRSHash("31UDQ", 0)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	_, err = MGRSHash("31UDQ4", Street)
+	assert.ErrorIs(t, err, ErrInvalidMGRS)
+
+	got, err = HashMGRS("u09tunq", 4)
+	require.NoError(t, err)
+	assert.Equal(t, "31UDQ48221193", got)
+
+	_, err = HashMGRS("zzz", 4)
+	assert.ErrorIs(t, err, ErrLatitudeOutOfRange)
+}
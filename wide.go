@@ -0,0 +1,201 @@
+package geohash
+
+const (
+	// MaxPrecision is the longest GeoHash accepted by Encode, Decode, DecodeBBox and Neighbor (125 bits).
+	// Precisions beyond SubPoint exceed the resolution of float64 coordinates near the end of the range,
+	// but remain useful as stable identifiers of sub-millimeter cells.
+	MaxPrecision Precision = 25
+
+	// maxIntBits is the largest bit depth of an integer GeoHash.
+	maxIntBits = 64
+
+	// maxHash128Bits is the largest bit depth of a Hash128.
+	maxHash128Bits = 128
+)
+
+// Hash128 is a GeoHash bitset of up to 128 bits, wide enough for any precision up to MaxPrecision.
+// The Bits low-order bits of Hi:Lo hold the interleaved bits, longitude first, as in a GeoHash string.
+type Hash128 struct {
+	Hi   uint64
+	Lo   uint64
+	Bits int
+}
+
+// EncodeInt returns the integer GeoHash of the given coordinates with any depth from 1 to 64 bits.
+// Returns an error if the coordinates or bit depth are out of range.
+func EncodeInt(latitude, longitude float64, bits int) (uint64, error) {
+	if err := checkPrecision(bits, 1, maxIntBits); err != nil {
+		return 0, err
+	}
+	h, err := EncodeHash128(latitude, longitude, bits)
+	if err != nil {
+		return 0, err
+	}
+	return h.Lo, nil
+}
+
+// MustEncodeInt returns the integer GeoHash of the given coordinates or panics if an error occurs.
+func MustEncodeInt(latitude, longitude float64, bits int) uint64 {
+	value, err := EncodeInt(latitude, longitude, bits)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// DecodeInt decodes an integer GeoHash of the given bit depth into its center coordinates and bounding box.
+// Returns an error if the bit depth is out of range, or an *IntError if the value does not fit in it.
+func DecodeInt(value uint64, bits int) (latitude, longitude float64, bbox BBox, err error) {
+	if err := checkPrecision(bits, 1, maxIntBits); err != nil {
+		return 0, 0, BBox{}, err
+	}
+	if bits < maxIntBits && value>>bits != 0 {
+		return 0, 0, BBox{}, &IntError{Value: value, Bits: bits}
+	}
+	latitude, longitude, bbox = Hash128{Lo: value, Bits: bits}.Decode()
+	return latitude, longitude, bbox, nil
+}
+
+// EncodeHash128 returns the GeoHash of the given coordinates with any depth from 1 to 128 bits.
+// Returns an error if the coordinates or bit depth are out of range.
+func EncodeHash128(latitude, longitude float64, bits int) (Hash128, error) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return Hash128{}, err
+	}
+	if err := checkPrecision(bits, 1, maxHash128Bits); err != nil {
+		return Hash128{}, err
+	}
+
+	latBits, lngBits := axisBits(bits)
+	return interlaceHash128(
+		encodeAxis(minLatitude, maxLatitude, latitude, latBits),
+		encodeAxis(minLongitude, maxLongitude, longitude, lngBits),
+		bits,
+	), nil
+}
+
+// ParseHash128 returns the bitset of a GeoHash string of up to MaxPrecision characters.
+// Returns an error if the hash is empty, too long or contains invalid characters.
+func ParseHash128(hash string) (Hash128, error) {
+	return StdEncoding.parse(hash)
+}
+
+// String returns the GeoHash string of the bitset. Trailing bits that do not fill a whole character are dropped.
+func (h Hash128) String() string {
+	return StdEncoding.format(h)
+}
+
+// Decode returns the center coordinates and bounding box of the cell identified by the bitset.
+func (h Hash128) Decode() (latitude, longitude float64, bbox BBox) {
+	latBitset, lngBitset := splitHash128(h)
+	latBits, lngBits := axisBits(h.Bits)
+	bbox.MinLatitude, bbox.MaxLatitude = decodeAxis(minLatitude, maxLatitude, latBitset, latBits)
+	bbox.MinLongitude, bbox.MaxLongitude = decodeAxis(minLongitude, maxLongitude, lngBitset, lngBits)
+	return (bbox.MinLatitude + bbox.MaxLatitude) / 2, (bbox.MinLongitude + bbox.MaxLongitude) / 2, bbox
+}
+
+// neighbor returns the adjacent cell at the given row and column offsets, wrapping around both axes.
+func (h Hash128) neighbor(dLat, dLng int) Hash128 {
+	latBitset, lngBitset := splitHash128(h)
+	latBits, lngBits := axisBits(h.Bits)
+	return interlaceHash128(
+		addWrapped(latBitset, dLat, latBits),
+		addWrapped(lngBitset, dLng, lngBits),
+		h.Bits,
+	)
+}
+
+// axisBits returns the number of latitude and longitude bits of a bitset; longitude gets the odd bit.
+func axisBits(totalBits int) (latBits, lngBits int) {
+	return totalBits / 2, (totalBits + 1) / 2
+}
+
+// encodeAxis returns the bitset of a value by binary partitioning of [leftBound, rightBound].
+func encodeAxis(leftBound, rightBound, value float64, bits int) uint64 {
+	var bitset uint64
+	for i := 0; i < bits; i++ {
+		avg := (leftBound + rightBound) / 2.0
+
+		bitset <<= 1
+
+		if value >= avg {
+			bitset |= 1
+			leftBound = avg
+			continue
+		}
+
+		rightBound = avg
+	}
+	return bitset
+}
+
+// decodeAxis returns the interval of [leftBound, rightBound] identified by a bitset.
+func decodeAxis(leftBound, rightBound float64, bitset uint64, bits int) (lower, upper float64) {
+	for i := 0; i < bits; i++ {
+		mid := (leftBound + rightBound) / 2.0
+
+		if (bitset>>(bits-1-i))&1 == 1 {
+			leftBound = mid
+		} else {
+			rightBound = mid
+		}
+	}
+	return leftBound, rightBound
+}
+
+// addWrapped adds delta to a bitset of the given width, wrapping around.
+func addWrapped(bitset uint64, delta int, bits int) uint64 {
+	bitset += uint64(delta)
+	if bits < 64 {
+		bitset &= 1<<bits - 1
+	}
+	return bitset
+}
+
+// interlaceHash128 interlaces latitude and longitude bitsets into a bitset of the given width.
+func interlaceHash128(latBitset, lngBitset uint64, bits int) Hash128 {
+	latBits, lngBits := axisBits(bits)
+
+	h := Hash128{Bits: bits}
+	for i := 0; i < bits; i++ {
+		h.Hi, h.Lo = shiftLeft128(h.Hi, h.Lo, 1)
+
+		if i%2 == 0 {
+			h.Lo |= (lngBitset >> (lngBits - 1 - i/2)) & 1
+			continue
+		}
+
+		h.Lo |= (latBitset >> (latBits - 1 - i/2)) & 1
+	}
+	return h
+}
+
+// splitHash128 splits a bitset into its latitude and longitude bitsets.
+func splitHash128(h Hash128) (latBitset, lngBitset uint64) {
+	for i := 0; i < h.Bits; i++ {
+		_, lo := shiftRight128(h.Hi, h.Lo, uint(h.Bits-1-i))
+
+		if i%2 == 0 {
+			lngBitset = lngBitset<<1 | lo&1
+		} else {
+			latBitset = latBitset<<1 | lo&1
+		}
+	}
+	return latBitset, lngBitset
+}
+
+// shiftLeft128 shifts the 128-bit value hi:lo left by n bits.
+func shiftLeft128(hi, lo uint64, n uint) (uint64, uint64) {
+	if n >= 64 {
+		return lo << (n - 64), 0
+	}
+	return hi<<n | lo>>(64-n), lo << n
+}
+
+// shiftRight128 shifts the 128-bit value hi:lo right by n bits.
+func shiftRight128(hi, lo uint64, n uint) (uint64, uint64) {
+	if n >= 64 {
+		return 0, hi >> (n - 64)
+	}
+	return hi >> n, lo>>n | hi<<(64-n)
+}
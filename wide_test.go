@@ -0,0 +1,128 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeBeyondSubPoint(t *testing.T) {
+	for precision := SubPoint + 1; precision <= MaxPrecision; precision++ {
+		hash, err := Encode(37.7749, -122.4194, precision)
+		require.NoError(t, err)
+		assert.Len(t, hash, int(precision))
+		assert.Equal(t, "9q8yyk8ytpxr", hash[:SubPoint])
+
+		lat, lng, bbox, err := DecodeBBox(hash)
+		require.NoError(t, err)
+		assert.InDelta(t, 37.7749, lat, tolerance)
+		assert.InDelta(t, -122.4194, lng, tolerance)
+		assert.True(t, bbox.Contains(37.7749, -122.4194))
+	}
+
+	lat, lng, err := Decode("9q8yyk8ytpxr8wwh")
+	require.NoError(t, err)
+	assert.InDelta(t, 37.7749, lat, tolerance)
+	assert.InDelta(t, -122.4194, lng, tolerance)
+}
+
+func TestNeighborBeyondSubPoint(t *testing.T) {
+	got, err := Neighbors("9q8yyk8ytpxrs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"9q8yyk8ytpxru", "9q8yyk8ytpxrv", "9q8yyk8ytpxrt", "9q8yyk8ytpxrm",
+		"9q8yyk8ytpxrk", "9q8yyk8ytpxr7", "9q8yyk8ytpxre", "9q8yyk8ytpxrg",
+	}, got)
+
+	hash := MustEncode(37.7749, -122.4194, MaxPrecision)
+	east := MustNeighbor(hash, E)
+	assert.NotEqual(t, hash, east)
+	assert.Equal(t, hash, MustNeighbor(east, W))
+}
+
+func TestEncodeInt(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		bits      int
+		want      uint64
+		wantErr   error
+	}{
+		{name: "Too few bits", bits: 0, wantErr: ErrPrecisionOutOfRange},
+		{name: "Too many bits", bits: 65, wantErr: ErrPrecisionOutOfRange},
+		{name: "Latitude out of range", latitude: 91, bits: 8, wantErr: ErrLatitudeOutOfRange},
+		{name: "Single bit", latitude: 37.7749, longitude: -122.4194, bits: 1, want: 0},
+		{name: "Odd bits", latitude: 37.7749, longitude: -122.4194, bits: 7, want: 0b0100110},
+		{name: "Whole characters", latitude: 37.7749, longitude: -122.4194, bits: 25, want: 10167262},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeInt(tt.latitude, tt.longitude, tt.bits)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// The integer GeoHash of whole characters is the bitset of the string GeoHash.
+	bitset, _, err := decodeFromBase32("9q8yy")
+	require.NoError(t, err)
+	assert.Equal(t, bitset, MustEncodeInt(37.7749, -122.4194, 25))
+	key, err := EncodeUint64(37.7749, -122.4194)
+	require.NoError(t, err)
+	assert.Equal(t, key, MustEncodeInt(37.7749, -122.4194, 64)>>4)
+}
+
+func TestDecodeInt(t *testing.T) {
+	lat, lng, bbox, err := DecodeInt(0x7f, 7)
+	require.NoError(t, err)
+	assert.Equal(t, BBox{MinLatitude: 67.5, MaxLatitude: 90, MinLongitude: 157.5, MaxLongitude: 180}, bbox)
+	assert.InDelta(t, 78.75, lat, tolerance)
+	assert.InDelta(t, 168.75, lng, tolerance)
+
+	_, _, wantBBox := MustDecodeBBox("9q8yy")
+	_, _, bbox, err = DecodeInt(10167262, 25)
+	require.NoError(t, err)
+	assert.Equal(t, wantBBox, bbox)
+
+	_, _, _, err = DecodeInt(0x80, 7)
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+	_, _, _, err = DecodeInt(0, 65)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+
+	value := MustEncodeInt(-33.8568, 151.2153, 64)
+	lat, lng, _, err = DecodeInt(value, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, -33.8568, lat, tolerance)
+	assert.InDelta(t, 151.2153, lng, tolerance)
+}
+
+func TestHash128(t *testing.T) {
+	h, err := EncodeHash128(37.7749, -122.4194, 128)
+	require.NoError(t, err)
+	assert.Equal(t, "9q8yyk8ytpxr8wwhcg8j25zzz", h.String())
+
+	_, err = EncodeHash128(37.7749, -122.4194, 129)
+	assert.ErrorIs(t, err, ErrPrecisionOutOfRange)
+	_, err = EncodeHash128(37.7749, 181, 64)
+	assert.ErrorIs(t, err, ErrLongitudeOutOfRange)
+
+	parsed, err := ParseHash128("9q8yyk8ytpxr8wwhcg8j25zzz")
+	require.NoError(t, err)
+	assert.Equal(t, 125, parsed.Bits)
+	assert.Equal(t, "9q8yyk8ytpxr8wwhcg8j25zzz", parsed.String())
+
+	lat, lng, bbox := parsed.Decode()
+	assert.InDelta(t, 37.7749, lat, tolerance)
+	assert.InDelta(t, -122.4194, lng, tolerance)
+	assert.True(t, bbox.Contains(37.7749, -122.4194))
+
+	_, err = ParseHash128("")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+	_, err = ParseHash128("9q8yyk8ytpxr8wwhcg8j25zzz0")
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+	_, err = ParseHash128("9q8yyk8ytpxra")
+	assert.ErrorIs(t, err, ErrInvalidHashFormat)
+}